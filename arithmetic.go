@@ -0,0 +1,269 @@
+package durago
+
+import (
+	"fmt"
+	"time"
+)
+
+// Add returns the sum of d and other as a new *Duration, a nil other is treated as zero.
+// Components are added individually; see Sum for how mixed signs are resolved.
+func (d *Duration) Add(other *Duration) *Duration {
+	return Sum(d, other)
+}
+
+// Sum adds all the given durations component by component and returns the result,
+// nil elements are treated as zero and an empty call returns PT0S.
+// If the summed components end up with different signs, which ISO8601 can't represent,
+// the result is rebuilt from the summed time.Duration as FromTimeDuration does.
+func Sum(durations ...*Duration) *Duration {
+	var (
+		years, months, weeks, days, hours, minutes int
+		seconds, total                             time.Duration
+	)
+
+	for _, d := range durations {
+		if d == nil {
+			continue
+		}
+
+		sign := 1
+		if d.negative {
+			sign = -1
+		}
+
+		years += sign * d.years
+		months += sign * d.months
+		weeks += sign * d.weeks
+		days += sign * d.days
+		hours += sign * d.hours
+		minutes += sign * d.minutes
+		// The seconds are summed as exact nanoseconds, the float is derived once below.
+		seconds += time.Duration(sign) * d.secondsDuration()
+		total += d.GetTimeDuration()
+	}
+
+	nonNegative := years >= 0 && months >= 0 && weeks >= 0 && days >= 0 && hours >= 0 && minutes >= 0 && seconds >= 0
+	nonPositive := years <= 0 && months <= 0 && weeks <= 0 && days <= 0 && hours <= 0 && minutes <= 0 && seconds <= 0
+
+	if !nonNegative && !nonPositive {
+		return FromTimeDuration(total)
+	}
+
+	result := &Duration{
+		d:        total,
+		years:    years,
+		months:   months,
+		weeks:    weeks,
+		days:     days,
+		hours:    hours,
+		minutes:  minutes,
+		seconds:  float64(seconds) / nsPerSecond,
+		negative: !nonNegative,
+	}
+
+	if result.negative {
+		result.d = -total
+		result.years = -years
+		result.months = -months
+		result.weeks = -weeks
+		result.days = -days
+		result.hours = -hours
+		result.minutes = -minutes
+		result.seconds = float64(-seconds) / nsPerSecond
+	}
+
+	return result
+}
+
+// SumStrings parses each input as an ISO 8601 duration and sums them with Sum, returning
+// both the resulting *Duration and its total time.Duration. It stops at the first parse error.
+func SumStrings(inputs ...string) (*Duration, time.Duration, error) {
+	durations := make([]*Duration, len(inputs))
+	for i, input := range inputs {
+		d, err := ParseDuration(input)
+		if err != nil {
+			return nil, 0, fmt.Errorf("input %d %q: %w", i, input, err)
+		}
+
+		durations[i] = d
+	}
+
+	sum := Sum(durations...)
+	return sum, sum.GetTimeDuration(), nil
+}
+
+// Compare compares d with other using their time.Duration values, a nil other is treated as zero.
+// It returns -1 if d is shorter than other, 1 if it's longer and 0 if they are equal.
+// Years and months use the approximate lengths, use CompareOn for an exact calendar comparison.
+func (d *Duration) Compare(other *Duration) int {
+	var o time.Duration
+	if other != nil {
+		o = other.GetTimeDuration()
+	}
+
+	switch v := d.GetTimeDuration(); {
+	case v < o:
+		return -1
+	case v > o:
+		return 1
+	}
+
+	return 0
+}
+
+// WithinTolerance reports whether the absolute difference between the time.Duration values of d and other
+// is at most tol, a nil other is treated as zero. Years and months use their approximate lengths.
+func (d *Duration) WithinTolerance(other *Duration, tol time.Duration) bool {
+	if tol < 0 {
+		return false
+	}
+
+	var o time.Duration
+	if other != nil {
+		o = other.GetTimeDuration()
+	}
+
+	a, b := d.GetTimeDuration(), o
+	if a < b {
+		a, b = b, a
+	}
+
+	// The difference may exceed the int64 range, but always fits into an uint64.
+	return uint64(a)-uint64(b) <= uint64(tol)
+}
+
+// SnapTo returns the option whose time.Duration value is closest to the one of d, preferring the larger
+// option on a tie. Signed values are compared, so -PT1M is closer to PT0S than to PT1M. Nil options are
+// skipped and nil is returned if there are no options.
+func (d *Duration) SnapTo(options []*Duration) *Duration {
+	var (
+		best     *Duration
+		bestDiff uint64
+	)
+
+	v := d.GetTimeDuration()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		o := option.GetTimeDuration()
+
+		a, b := v, o
+		if a < b {
+			a, b = b, a
+		}
+
+		// The difference may exceed the int64 range, but always fits into an uint64.
+		diff := uint64(a) - uint64(b)
+		if best == nil || diff < bestDiff || (diff == bestDiff && o > best.GetTimeDuration()) {
+			best, bestDiff = option, diff
+		}
+	}
+
+	return best
+}
+
+// Sub returns the difference of d and other as a new *Duration, a nil other is treated as zero.
+// Components are subtracted individually; see Sum for how mixed signs are resolved.
+func (d *Duration) Sub(other *Duration) *Duration {
+	if other == nil {
+		return Sum(d)
+	}
+
+	negated := *other
+	negated.negative = !other.negative
+
+	return Sum(d, &negated)
+}
+
+// DifferenceFrom returns how much d differs from other as a non-negative *Duration, together with
+// 1 if d is longer, -1 if it's shorter and 0 if they are equal, e.g. P3D from P1D gives P2D and 1.
+// The difference is computed with Sub, so components are subtracted individually where the signs allow it.
+func (d *Duration) DifferenceFrom(other *Duration) (*Duration, int) {
+	diff := d.Sub(other)
+
+	switch {
+	case diff.d == 0:
+		return &Duration{}, 0
+	case diff.negative:
+		diff.negative = false
+		return diff, -1
+	}
+
+	return diff, 1
+}
+
+// Split divides the duration into parts clock durations whose time.Duration values add up to exactly the one of d.
+// The nanoseconds that can't be divided evenly are spread one each over the first parts, so PT10S split
+// into 3 gives PT3.333333334S, PT3.333333333S and PT3.333333333S. It returns nil if parts isn't positive.
+func (d *Duration) Split(parts int) []*Duration {
+	if parts <= 0 {
+		return nil
+	}
+
+	total := d.GetTimeDuration()
+	share := total / time.Duration(parts)
+	remainder := int(total % time.Duration(parts))
+
+	step := time.Duration(1)
+	if remainder < 0 {
+		step, remainder = -1, -remainder
+	}
+
+	result := make([]*Duration, parts)
+	for i := range result {
+		v := share
+		if i < remainder {
+			v += step
+		}
+
+		result[i] = FromTimeDurationClock(v)
+	}
+
+	return result
+}
+
+// GCD returns the greatest common divisor of the absolute time.Duration values of the given durations,
+// i.e. the coarsest tick evenly dividing all of them. Zero and nil durations are skipped,
+// if nothing is left PT0S is returned. The result is built with FromTimeDuration.
+func GCD(durations ...*Duration) *Duration {
+	var gcd time.Duration
+
+	for _, d := range durations {
+		if d == nil || d.d == 0 {
+			continue
+		}
+
+		a, b := gcd, d.d
+		for b != 0 {
+			a, b = b, a%b
+		}
+
+		gcd = a
+	}
+
+	return FromTimeDuration(gcd)
+}
+
+// DivisibleBy reports whether the time.Duration value of d is a whole multiple of the one of unit,
+// e.g. PT1H is divisible by PT15M but not by PT7M. Signs are ignored, a zero or nil unit returns false.
+// Years and months use their approximate lengths.
+func (d *Duration) DivisibleBy(unit *Duration) bool {
+	if unit == nil || unit.d == 0 {
+		return false
+	}
+
+	return d.d%unit.d == 0
+}
+
+// PercentOf returns how many percent of total the duration is, using their signed time.Duration values,
+// so PT30M of PT1H is 50 and -PT30M of PT1H is -50. The result isn't clamped, PT2H of PT1H is 200.
+// A zero or nil total returns 0.
+func (d *Duration) PercentOf(total *Duration) float64 {
+	if total == nil || total.d == 0 {
+		return 0
+	}
+
+	return float64(d.GetTimeDuration()) / float64(total.GetTimeDuration()) * 100
+}
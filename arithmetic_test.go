@@ -0,0 +1,431 @@
+package durago
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSum(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Durations []string
+		Expected  string
+	}{
+		{
+			Name:     "empty",
+			Expected: "PT0S",
+		},
+		{
+			Name:      "single",
+			Durations: []string{"P1DT2H"},
+			Expected:  "P1DT2H",
+		},
+		{
+			Name:      "positive",
+			Durations: []string{"P1Y2M", "P3DT4H", "PT5M6.5S"},
+			Expected:  "P1Y2M3DT4H5M6.5S",
+		},
+		{
+			Name:      "negative",
+			Durations: []string{"-PT1H", "-PT30M"},
+			Expected:  "-PT1H30M",
+		},
+		{
+			Name:      "mixed sign same components",
+			Durations: []string{"PT2H", "-PT3H"},
+			Expected:  "-PT1H",
+		},
+		{
+			Name:      "mixed sign to zero",
+			Durations: []string{"PT2H", "-PT2H"},
+			Expected:  "PT0S",
+		},
+		{
+			Name:      "fractional seconds",
+			Durations: []string{"PT0.1S", "PT0.2S"},
+			Expected:  "PT0.3S",
+		},
+		{
+			Name:      "fractional seconds with mixed signs",
+			Durations: []string{"PT0.3S", "-PT0.1S"},
+			Expected:  "PT0.2S",
+		},
+		{
+			Name:      "mixed sign crossing zero across components",
+			Durations: []string{"PT1H", "-PT90M"},
+			Expected:  "-PT30M",
+		},
+		{
+			Name:      "mixed sign positive across components",
+			Durations: []string{"P1D", "-PT1H"},
+			Expected:  "PT23H",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			durations := make([]*Duration, 0, len(c.Durations))
+			var expected time.Duration
+			for _, s := range c.Durations {
+				d, err := ParseDuration(s)
+				if err != nil {
+					t.Fatalf("expected to parse duration; got %v", err)
+				}
+				durations = append(durations, d)
+				expected += d.GetTimeDuration()
+			}
+
+			got := Sum(durations...)
+			if got.String() != c.Expected {
+				t.Fatalf("expected duration %s; got %s", c.Expected, got)
+			}
+
+			if got.GetTimeDuration() != expected {
+				t.Fatalf("expected time duration %d; got %d", expected, got.GetTimeDuration())
+			}
+		})
+	}
+}
+
+func TestSumStrings(t *testing.T) {
+	d, total, err := SumStrings("P1D", "PT2H", "-PT30M")
+	if err != nil {
+		t.Fatalf("expected no error; got %v", err)
+	}
+
+	if d.String() != "P1DT1H30M" {
+		t.Fatalf("expected duration P1DT1H30M; got %s", d)
+	}
+
+	if total != 25*time.Hour+30*time.Minute {
+		t.Fatalf("expected total %s; got %s", 25*time.Hour+30*time.Minute, total)
+	}
+
+	d, total, err = SumStrings()
+	if err != nil || d.String() != "PT0S" || total != 0 {
+		t.Fatalf("expected zero sum for no inputs; got %v, %s, %v", d, total, err)
+	}
+
+	_, _, err = SumStrings("PT1H", "PT1X", "bogus")
+	if !errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("expected ErrInvalidFormat; got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), `input 1 "PT1X"`) {
+		t.Fatalf("expected error to name the failing input; got %v", err)
+	}
+}
+
+func TestSum_Nil(t *testing.T) {
+	d, _ := ParseDuration("PT1H")
+
+	got := Sum(nil, d, nil)
+	if got.String() != "PT1H" {
+		t.Fatalf("expected duration PT1H; got %s", got)
+	}
+}
+
+func TestDuration_Add(t *testing.T) {
+	a, _ := ParseDuration("P1DT1H")
+	b, _ := ParseDuration("PT30M")
+
+	got := a.Add(b)
+	if got.String() != "P1DT1H30M" {
+		t.Fatalf("expected duration P1DT1H30M; got %s", got)
+	}
+
+	if a.String() != "P1DT1H" {
+		t.Fatalf("expected receiver to be unchanged; got %s", a)
+	}
+
+	if got := a.Add(nil); got.String() != "P1DT1H" {
+		t.Fatalf("expected duration P1DT1H; got %s", got)
+	}
+}
+
+func TestDuration_Compare(t *testing.T) {
+	cases := []struct {
+		Left     string
+		Right    string
+		Expected int
+	}{
+		{Left: "PT1H", Right: "PT60M", Expected: 0},
+		{Left: "PT1H", Right: "PT59M", Expected: 1},
+		{Left: "-PT1H", Right: "PT1S", Expected: -1},
+		{Left: "P1M", Right: "P30D", Expected: 1},
+	}
+
+	for _, c := range cases {
+		left, _ := ParseDuration(c.Left)
+		right, _ := ParseDuration(c.Right)
+
+		if got := left.Compare(right); got != c.Expected {
+			t.Fatalf("expected %s compared to %s to be %d; got %d", c.Left, c.Right, c.Expected, got)
+		}
+	}
+
+	d, _ := ParseDuration("-PT1S")
+	if got := d.Compare(nil); got != -1 {
+		t.Fatalf("expected -1 compared to nil; got %d", got)
+	}
+}
+
+func TestDuration_Sub(t *testing.T) {
+	cases := []struct {
+		Left     string
+		Right    string
+		Expected string
+	}{
+		{Left: "PT1M", Right: "PT30S", Expected: "PT30S"},
+		{Left: "P1DT2H", Right: "PT1H", Expected: "P1DT1H"},
+		{Left: "PT1H", Right: "PT2H", Expected: "-PT1H"},
+		{Left: "-PT1H", Right: "-PT1H", Expected: "PT0S"},
+	}
+
+	for _, c := range cases {
+		left, _ := ParseDuration(c.Left)
+		right, _ := ParseDuration(c.Right)
+
+		if got := left.Sub(right); got.String() != c.Expected {
+			t.Fatalf("expected %s - %s to be %s; got %s", c.Left, c.Right, c.Expected, got)
+		}
+	}
+
+	d, _ := ParseDuration("PT1H")
+	if got := d.Sub(nil); got.String() != "PT1H" {
+		t.Fatalf("expected duration PT1H; got %s", got)
+	}
+}
+
+func TestDuration_DifferenceFrom(t *testing.T) {
+	cases := []struct {
+		Left     string
+		Right    string
+		Expected string
+		Sign     int
+	}{
+		{Left: "P3D", Right: "P1D", Expected: "P2D", Sign: 1},
+		{Left: "PT1H", Right: "PT3H", Expected: "PT2H", Sign: -1},
+		{Left: "PT90M", Right: "PT1H30M", Expected: "PT0S", Sign: 0},
+		{Left: "-PT1H", Right: "PT1H", Expected: "PT2H", Sign: -1},
+		{Left: "P1D", Right: "PT1H", Expected: "PT23H", Sign: 1},
+	}
+
+	for _, c := range cases {
+		left, _ := ParseDuration(c.Left)
+		right, _ := ParseDuration(c.Right)
+
+		got, sign := left.DifferenceFrom(right)
+		if got.String() != c.Expected || sign != c.Sign {
+			t.Fatalf("expected %s compared to %s to differ by %s with sign %d; got %s with sign %d", c.Left, c.Right, c.Expected, c.Sign, got, sign)
+		}
+	}
+}
+
+func TestDuration_SnapTo(t *testing.T) {
+	var options []*Duration
+	for _, s := range []string{"PT5M", "PT15M", "PT30M", "PT1H", "-PT10M"} {
+		d, _ := ParseDuration(s)
+		options = append(options, d)
+	}
+
+	cases := []struct {
+		Duration string
+		Expected string
+	}{
+		{Duration: "PT7M", Expected: "PT5M"},
+		{Duration: "PT10M", Expected: "PT15M"},
+		{Duration: "PT22M30S", Expected: "PT30M"},
+		{Duration: "P1D", Expected: "PT1H"},
+		{Duration: "PT0S", Expected: "PT5M"},
+		{Duration: "-PT2M", Expected: "PT5M"},
+		{Duration: "-PT2M30S", Expected: "PT5M"},
+		{Duration: "-PT4M", Expected: "-PT10M"},
+		{Duration: "PT30M", Expected: "PT30M"},
+	}
+
+	for _, c := range cases {
+		d, _ := ParseDuration(c.Duration)
+		if got := d.SnapTo(options); got.String() != c.Expected {
+			t.Fatalf("expected %s to snap to %s; got %s", c.Duration, c.Expected, got)
+		}
+	}
+
+	if got := options[0].SnapTo(nil); got != nil {
+		t.Fatalf("expected nil for no options; got %s", got)
+	}
+
+	if got := options[0].SnapTo([]*Duration{nil}); got != nil {
+		t.Fatalf("expected nil for only nil options; got %s", got)
+	}
+}
+
+func TestDuration_Split(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Parts    int
+		Expected []string
+	}{
+		{Duration: "PT10S", Parts: 3, Expected: []string{"PT3.333333334S", "PT3.333333333S", "PT3.333333333S"}},
+		{Duration: "PT1H", Parts: 4, Expected: []string{"PT15M", "PT15M", "PT15M", "PT15M"}},
+		{Duration: "-PT0.000000005S", Parts: 2, Expected: []string{"-PT0.000000003S", "-PT0.000000002S"}},
+		{Duration: "PT0.000000002S", Parts: 3, Expected: []string{"PT0.000000001S", "PT0.000000001S", "PT0S"}},
+		{Duration: "P1D", Parts: 1, Expected: []string{"PT24H"}},
+		{Duration: "PT1H", Parts: 0, Expected: nil},
+		{Duration: "PT1H", Parts: -1, Expected: nil},
+	}
+
+	for _, c := range cases {
+		d, _ := ParseDuration(c.Duration)
+
+		got := d.Split(c.Parts)
+		if len(got) != len(c.Expected) {
+			t.Fatalf("expected %s split into %d to give %d parts; got %d", c.Duration, c.Parts, len(c.Expected), len(got))
+		}
+
+		var total time.Duration
+		for i, part := range got {
+			if part.String() != c.Expected[i] {
+				t.Fatalf("expected part %d of %s to be %s; got %s", i, c.Duration, c.Expected[i], part)
+			}
+
+			total += part.GetTimeDuration()
+		}
+
+		if len(got) > 0 && total != d.GetTimeDuration() {
+			t.Fatalf("expected parts of %s to add up to %d; got %d", c.Duration, d.GetTimeDuration(), total)
+		}
+	}
+}
+
+func TestGCD(t *testing.T) {
+	cases := []struct {
+		Durations []string
+		Expected  string
+	}{
+		{Expected: "PT0S"},
+		{Durations: []string{"PT0S", "P0D"}, Expected: "PT0S"},
+		{Durations: []string{"PT30S", "PT45S"}, Expected: "PT15S"},
+		{Durations: []string{"PT30S", "PT0S", "PT45S"}, Expected: "PT15S"},
+		{Durations: []string{"PT1H", "PT40M", "-PT25M"}, Expected: "PT5M"},
+		{Durations: []string{"P1D", "P2D"}, Expected: "P1D"},
+		{Durations: []string{"PT1.5S", "PT1S"}, Expected: "PT0.5S"},
+		{Durations: []string{"PT7S"}, Expected: "PT7S"},
+	}
+
+	for _, c := range cases {
+		durations := make([]*Duration, 0, len(c.Durations)+1)
+		for _, s := range c.Durations {
+			d, err := ParseDuration(s)
+			if err != nil {
+				t.Fatalf("expected to parse duration; got %v", err)
+			}
+			durations = append(durations, d)
+		}
+		durations = append(durations, nil)
+
+		if got := GCD(durations...); got.String() != c.Expected {
+			t.Fatalf("expected GCD of %v to be %s; got %s", c.Durations, c.Expected, got)
+		}
+	}
+}
+
+func TestDuration_DivisibleBy(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Unit     string
+		Expected bool
+	}{
+		{Duration: "PT1H", Unit: "PT15M", Expected: true},
+		{Duration: "PT1H", Unit: "PT7M", Expected: false},
+		{Duration: "P1D", Unit: "PT1H", Expected: true},
+		{Duration: "-PT1H", Unit: "PT20M", Expected: true},
+		{Duration: "PT1H", Unit: "-PT30M", Expected: true},
+		{Duration: "PT0S", Unit: "PT1M", Expected: true},
+		{Duration: "PT1M", Unit: "PT0.7S", Expected: false},
+		{Duration: "PT1H", Unit: "PT0S", Expected: false},
+		{Duration: "PT15M", Unit: "PT1H", Expected: false},
+	}
+
+	for _, c := range cases {
+		d, _ := ParseDuration(c.Duration)
+		unit, _ := ParseDuration(c.Unit)
+
+		if got := d.DivisibleBy(unit); got != c.Expected {
+			t.Fatalf("expected %s divisible by %s to be %t; got %t", c.Duration, c.Unit, c.Expected, got)
+		}
+	}
+
+	if d, _ := ParseDuration("PT1H"); d.DivisibleBy(nil) {
+		t.Fatalf("expected a nil unit not to divide")
+	}
+}
+
+func TestDuration_PercentOf(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Total    string
+		Expected float64
+	}{
+		{Duration: "PT30M", Total: "PT1H", Expected: 50},
+		{Duration: "PT0S", Total: "PT1H", Expected: 0},
+		{Duration: "PT2H", Total: "PT1H", Expected: 200},
+		{Duration: "-PT30M", Total: "PT1H", Expected: -50},
+		{Duration: "-PT15M", Total: "-PT1H", Expected: 25},
+		{Duration: "PT30M", Total: "PT0S", Expected: 0},
+	}
+
+	for _, c := range cases {
+		d, _ := ParseDuration(c.Duration)
+		total, _ := ParseDuration(c.Total)
+
+		if got := d.PercentOf(total); got != c.Expected {
+			t.Fatalf("expected %s of %s to be %v%%; got %v%%", c.Duration, c.Total, c.Expected, got)
+		}
+	}
+
+	d, _ := ParseDuration("PT1H")
+	if got := d.PercentOf(nil); got != 0 {
+		t.Fatalf("expected 0%% of nil; got %v%%", got)
+	}
+}
+
+func TestDuration_WithinTolerance(t *testing.T) {
+	cases := []struct {
+		Left      string
+		Right     string
+		Tolerance time.Duration
+		Expected  bool
+	}{
+		{Left: "PT1S", Right: "PT1S", Tolerance: 0, Expected: true},
+		{Left: "PT1.001S", Right: "PT1S", Tolerance: time.Millisecond, Expected: true},
+		{Left: "PT1S", Right: "PT1.001S", Tolerance: time.Millisecond, Expected: true},
+		{Left: "PT1.0011S", Right: "PT1S", Tolerance: time.Millisecond, Expected: false},
+		{Left: "-PT0.5S", Right: "PT0.5S", Tolerance: time.Second, Expected: true},
+		{Left: "-PT0.5S", Right: "PT0.5S", Tolerance: time.Second - 1, Expected: false},
+		{Left: "PT1S", Right: "PT1S", Tolerance: -1, Expected: false},
+	}
+
+	for _, c := range cases {
+		left, _ := ParseDuration(c.Left)
+		right, _ := ParseDuration(c.Right)
+
+		if got := left.WithinTolerance(right, c.Tolerance); got != c.Expected {
+			t.Fatalf("expected %s within %s of %s to be %t; got %t", c.Left, c.Tolerance, c.Right, c.Expected, got)
+		}
+	}
+
+	largest := MaxDuration()
+	smallest := largest.Sub(largest).Sub(largest)
+	if largest.WithinTolerance(smallest, time.Duration(math.MaxInt64)) {
+		t.Fatalf("expected extreme durations not to be within tolerance")
+	}
+
+	d, _ := ParseDuration("PT1S")
+	if !d.WithinTolerance(nil, time.Second) {
+		t.Fatalf("expected PT1S to be within 1s of nil")
+	}
+}
@@ -0,0 +1,40 @@
+package durago
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// parseCacheSize caps the number of distinct inputs remembered by ParseDurationCached.
+const parseCacheSize = 1024
+
+var (
+	parseCache     sync.Map
+	parseCacheSeen atomic.Int64
+)
+
+// ParseDurationCached works like ParseDuration but memoizes successful parses, which pays off
+// when the same handful of strings is parsed over and over. Every call returns a fresh copy,
+// so the result can be modified freely. Once the cache holds parseCacheSize entries new inputs
+// are parsed without being cached. It's safe for concurrent use.
+func ParseDurationCached(s string) (*Duration, error) {
+	if cached, ok := parseCache.Load(s); ok {
+		duration := cached.(Duration)
+		return &duration, nil
+	}
+
+	duration, err := ParseDuration(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// A slot is reserved before storing and given back if it isn't used,
+	// so concurrent misses can't push the cache past parseCacheSize.
+	if parseCacheSeen.Add(1) > parseCacheSize {
+		parseCacheSeen.Add(-1)
+	} else if _, loaded := parseCache.LoadOrStore(s, *duration); loaded {
+		parseCacheSeen.Add(-1)
+	}
+
+	return duration, nil
+}
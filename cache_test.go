@@ -0,0 +1,85 @@
+package durago
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestParseDurationCached(t *testing.T) {
+	expected, err := ParseDuration("P1DT2H30.5S")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	first, err := ParseDurationCached("P1DT2H30.5S")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	second, err := ParseDurationCached("P1DT2H30.5S")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	if !reflect.DeepEqual(first, expected) || !reflect.DeepEqual(second, expected) {
+		t.Fatalf("expected duration %s; got %s and %s", expected, first, second)
+	}
+
+	if first == second {
+		t.Fatalf("expected cached results to be copies")
+	}
+
+	*second = Duration{}
+
+	third, _ := ParseDurationCached("P1DT2H30.5S")
+	if !reflect.DeepEqual(third, expected) {
+		t.Fatalf("expected modifying a result not to affect the cache; got %s", third)
+	}
+
+	if _, err := ParseDurationCached("P1X"); err == nil {
+		t.Fatalf("expected error for invalid duration")
+	}
+
+	if _, ok := parseCache.Load("P1X"); ok {
+		t.Fatalf("expected errors not to be cached")
+	}
+}
+
+func TestParseDurationCached_Bounded(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for i := range parseCacheSize * 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ParseDurationCached("PT" + strconv.Itoa(i) + "S")
+		}()
+	}
+
+	wg.Wait()
+
+	var size int
+	parseCache.Range(func(_, _ any) bool {
+		size++
+		return true
+	})
+
+	if size > parseCacheSize {
+		t.Fatalf("expected at most %d cached entries; got %d", parseCacheSize, size)
+	}
+
+	d, err := ParseDurationCached("PT" + strconv.Itoa(parseCacheSize*3) + "S")
+	if err != nil || d.String() != "PT"+strconv.Itoa(parseCacheSize*3)+"S" {
+		t.Fatalf("expected to parse duration past the cache size; got %v, %v", d, err)
+	}
+}
+
+func BenchmarkParseDurationCached(b *testing.B) {
+	duration := "+P3Y6M1W4DT12H30M5S"
+
+	for b.Loop() {
+		ParseDurationCached(duration)
+	}
+}
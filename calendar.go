@@ -0,0 +1,282 @@
+package durago
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// AddTo returns t shifted by the duration using calendar arithmetic.
+// Years, months, weeks and days are applied with time.AddDate, so their length depends on t,
+// the remaining hours, minutes and seconds are applied with time.Add.
+func (d *Duration) AddTo(t time.Time) time.Time {
+	sign := 1
+	if d.negative {
+		sign = -1
+	}
+
+	t = t.AddDate(sign*d.years, sign*d.months, sign*(d.weeks*7+d.days))
+
+	return t.Add(time.Duration(sign) * d.clockDuration())
+}
+
+// Deadline returns the point in time the duration ends at when started at from, the same as AddTo.
+func (d *Duration) Deadline(from time.Time) time.Time {
+	return d.AddTo(from)
+}
+
+// Context returns a copy of parent that is cancelled once the duration, started now, has elapsed.
+// It's a shortcut for context.WithDeadline with d.Deadline(time.Now()).
+func (d *Duration) Context(parent context.Context) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(parent, d.Deadline(time.Now()))
+}
+
+// AddBusinessDays works like AddTo but treats the days component as business days, skipping Saturdays and Sundays,
+// so P3D added to a Thursday lands on the following Tuesday. Years, months and weeks are applied as calendar units first.
+// Weeks keep the weekday, but years and months can land on a weekend: the business days are then counted from there,
+// so P1M1D reaching a Saturday ends on Monday, while P1M alone stays on the Saturday.
+// The hours, minutes and seconds are added last. Holidays aren't taken into account.
+func (d *Duration) AddBusinessDays(t time.Time) time.Time {
+	sign := 1
+	if d.negative {
+		sign = -1
+	}
+
+	t = t.AddDate(sign*d.years, sign*d.months, sign*d.weeks*7)
+
+	for remaining := d.days; remaining > 0; {
+		t = t.AddDate(0, 0, sign)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			remaining--
+		}
+	}
+
+	return t.Add(time.Duration(sign) * d.clockDuration())
+}
+
+// Range returns an iterator over start, start+d, start+2d, … up to but not including end.
+// Every point is calculated from start with the multiplied duration using calendar arithmetic like AddTo,
+// so stepping P1M from January 31 reaches March 31 instead of drifting to the 3rd after passing February.
+// A zero or negative duration yields nothing.
+func (d *Duration) Range(start, end time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if d.negative || d.d == 0 {
+			return
+		}
+
+		for n := 0; ; n++ {
+			t := d.addTimesTo(start, n)
+			if !t.Before(end) || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// NextAligned returns the first time after from that is a whole multiple of the duration since the Unix epoch,
+// e.g. 10:15 for PT15M and 10:07. It's NextAlignedTo with the Unix epoch as anchor.
+func (d *Duration) NextAligned(from time.Time) time.Time {
+	return d.NextAlignedTo(from, time.Unix(0, 0))
+}
+
+// NextAlignedTo returns the first time after from that is a whole multiple of the duration since anchor.
+// Only clock durations can be aligned, for durations with years, months, weeks or days the result is
+// from shifted with AddTo. The sign of the duration is ignored and a zero duration returns from.
+func (d *Duration) NextAlignedTo(from, anchor time.Time) time.Time {
+	if d.hasCalendar() {
+		return d.AddTo(from)
+	}
+
+	step := d.d
+	if step <= 0 {
+		return from
+	}
+
+	offset := from.Sub(anchor) % step
+	if offset < 0 {
+		offset += step
+	}
+
+	return from.Add(step - offset)
+}
+
+// CompareOn compares d with other by applying both to the reference time with AddTo.
+// It returns -1 if d is shorter than other, 1 if it's longer and 0 if both land on the same instant.
+// Unlike Compare, which relies on the approximate year and month lengths, CompareOn is exact.
+func (d *Duration) CompareOn(reference time.Time, other *Duration) int {
+	if other == nil {
+		other = &Duration{}
+	}
+
+	return d.AddTo(reference).Compare(other.AddTo(reference))
+}
+
+// TotalDaysFrom returns the exact number of 24 hour days the duration spans when applied to the
+// reference time with AddTo, e.g. P1M starting on February 1 spans 28 or 29 days depending on the year.
+func (d *Duration) TotalDaysFrom(reference time.Time) float64 {
+	return float64(d.AddTo(reference).Sub(reference)) / float64(periodDay)
+}
+
+// InMonths returns the exact number of calendar months the duration spans when applied to the reference time
+// with AddTo, a partial month counts by its real length, e.g. P14D starting on February 1, 2023 is 0.5 months.
+func (d *Duration) InMonths(reference time.Time) float64 {
+	return calendarUnitsBetween(reference, d.AddTo(reference), 1)
+}
+
+// InYears works like InMonths but counts calendar years, so a partial year depends on whether it's a leap year.
+func (d *Duration) InYears(reference time.Time) float64 {
+	return calendarUnitsBetween(reference, d.AddTo(reference), 12)
+}
+
+// calendarUnitsBetween returns the number of whole units of the given months from start to end,
+// plus the fraction of the unit containing end.
+func calendarUnitsBetween(start, end time.Time, months int) float64 {
+	n := int(end.Sub(start) / (time.Duration(months) * periodMonth))
+	for start.AddDate(0, n*months, 0).After(end) {
+		n--
+	}
+
+	for !start.AddDate(0, (n+1)*months, 0).After(end) {
+		n++
+	}
+
+	lower := start.AddDate(0, n*months, 0)
+	upper := start.AddDate(0, (n+1)*months, 0)
+
+	return float64(n) + float64(end.Sub(lower))/float64(upper.Sub(lower))
+}
+
+// SubOn returns d minus other, calculated as the calendar difference between applying other
+// and d to the reference time with AddTo. Unlike Sub it borrows across units correctly,
+// e.g. P1M minus P1D is P27D in February 2023 and P29D in April 2023. A nil other is treated as zero.
+func (d *Duration) SubOn(reference time.Time, other *Duration) *Duration {
+	if other == nil {
+		other = &Duration{}
+	}
+
+	return DurationBetween(other.AddTo(reference), d.AddTo(reference))
+}
+
+// DurationBetween returns the calendar duration from start to end by counting whole years, months
+// and days followed by the remaining clock time. Applying the result to start with AddTo lands exactly on end,
+// if end is before start the duration is negative. Unlike FromTimeDuration, years and months are accurate.
+func DurationBetween(start, end time.Time) *Duration {
+	end = end.In(start.Location())
+
+	sign := 1
+	if end.Before(start) {
+		sign = -1
+	}
+
+	// beyond reports whether t went past end in the direction we are walking.
+	beyond := func(t time.Time) bool {
+		return t.Compare(end) == sign
+	}
+
+	months := sign * ((end.Year()-start.Year())*12 + int(end.Month()-start.Month()))
+	base := start.AddDate(0, sign*months, 0)
+	for months > 0 && beyond(base) {
+		months--
+		base = start.AddDate(0, sign*months, 0)
+	}
+
+	days := sign * int(end.Sub(base)/periodDay)
+	for days > 0 && beyond(base.AddDate(0, 0, sign*days)) {
+		days--
+	}
+
+	for !beyond(base.AddDate(0, 0, sign*(days+1))) {
+		days++
+	}
+
+	clock := time.Duration(sign) * end.Sub(base.AddDate(0, 0, sign*days))
+
+	return newDuration(sign < 0, months/12, months%12, 0, days, clock)
+}
+
+// Overlap returns the length of the intersection of the intervals starting at start1 and start2 and lasting
+// d1 and d2, whose ends are computed with AddTo, and whether they overlap at all. An interval of a negative
+// duration ends before its start. The length is an exact clock duration as built by FromTimeDurationClock.
+// Intervals that only touch don't overlap and return a zero duration and false. A nil duration is treated as zero.
+func Overlap(start1 time.Time, d1 *Duration, start2 time.Time, d2 *Duration) (*Duration, bool) {
+	if d1 == nil {
+		d1 = &Duration{}
+	}
+
+	if d2 == nil {
+		d2 = &Duration{}
+	}
+
+	from1, to1 := start1, d1.AddTo(start1)
+	if to1.Before(from1) {
+		from1, to1 = to1, from1
+	}
+
+	from2, to2 := start2, d2.AddTo(start2)
+	if to2.Before(from2) {
+		from2, to2 = to2, from2
+	}
+
+	from := from1
+	if from2.After(from) {
+		from = from2
+	}
+
+	to := to1
+	if to2.Before(to) {
+		to = to2
+	}
+
+	if !to.After(from) {
+		return &Duration{}, false
+	}
+
+	return FromTimeDurationClock(to.Sub(from)), true
+}
+
+// newDuration builds a *Duration from its calendar components and the unsigned clock time,
+// which is split into hours, minutes and seconds.
+func newDuration(negative bool, years, months, weeks, days int, clock time.Duration) *Duration {
+	duration := &Duration{
+		negative: negative,
+		years:    years,
+		months:   months,
+		weeks:    weeks,
+		days:     days,
+	}
+
+	duration.d = time.Duration(years)*periodYear + time.Duration(months)*periodMonth +
+		time.Duration(weeks)*periodWeek + time.Duration(days)*periodDay + clock
+
+	duration.hours = int(clock / nsPerHour)
+	clock -= time.Duration(duration.hours) * nsPerHour
+	duration.minutes = int(clock / nsPerMinute)
+	clock -= time.Duration(duration.minutes) * nsPerMinute
+	// Dividing once rounds correctly, so the seconds format back to exactly the nanoseconds.
+	duration.seconds = float64(clock) / nsPerSecond
+
+	if duration.d == 0 {
+		duration.negative = false
+	}
+
+	return duration
+}
+
+// addTimesTo returns t shifted by n times the duration, with every component multiplied before applying it.
+func (d *Duration) addTimesTo(t time.Time, n int) time.Time {
+	if d.negative {
+		n = -n
+	}
+
+	t = t.AddDate(n*d.years, n*d.months, n*(d.weeks*7+d.days))
+
+	return t.Add(time.Duration(n) * d.clockDuration())
+}
+
+// clockDuration returns the unsigned time.Duration of the hours, minutes and seconds components.
+func (d *Duration) clockDuration() time.Duration {
+	calendar := time.Duration(d.years)*periodYear + time.Duration(d.months)*periodMonth +
+		time.Duration(d.weeks)*periodWeek + time.Duration(d.days)*periodDay
+
+	return d.d - calendar
+}
@@ -0,0 +1,542 @@
+package durago
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDuration_AddTo(t *testing.T) {
+	cases := []struct {
+		Duration  string
+		Reference time.Time
+		Expected  time.Time
+	}{
+		{
+			Duration:  "P1M",
+			Reference: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Duration:  "P1Y2M1W3DT4H5M6.5S",
+			Reference: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  time.Date(2024, time.March, 11, 4, 5, 6, 500000000, time.UTC),
+		},
+		{
+			Duration:  "-P1DT1H",
+			Reference: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  time.Date(2024, time.February, 28, 23, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Duration, func(t *testing.T) {
+			d, err := ParseDuration(c.Duration)
+			if err != nil {
+				t.Fatalf("expected to parse duration; got %v", err)
+			}
+
+			got := d.AddTo(c.Reference)
+			if !got.Equal(c.Expected) {
+				t.Fatalf("expected time %s; got %s", c.Expected, got)
+			}
+		})
+	}
+}
+
+func TestDuration_Deadline(t *testing.T) {
+	d, _ := ParseDuration("P1MT2H")
+	from := time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC)
+
+	expected := time.Date(2024, time.March, 2, 12, 0, 0, 0, time.UTC)
+	if got := d.Deadline(from); !got.Equal(expected) {
+		t.Fatalf("expected deadline %s; got %s", expected, got)
+	}
+}
+
+func TestDuration_Context(t *testing.T) {
+	d, _ := ParseDuration("PT1H")
+
+	before := time.Now()
+	ctx, cancel := d.Context(context.Background())
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatalf("expected context to have a deadline")
+	}
+
+	if deadline.Before(before.Add(time.Hour)) || deadline.After(time.Now().Add(time.Hour)) {
+		t.Fatalf("expected deadline about an hour from now; got %s", deadline)
+	}
+
+	expired, cancel := (&Duration{}).Context(context.Background())
+	defer cancel()
+
+	<-expired.Done()
+	if expired.Err() != context.DeadlineExceeded {
+		t.Fatalf("expected zero duration context to be expired; got %v", expired.Err())
+	}
+}
+
+func TestDuration_NextAligned(t *testing.T) {
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		Duration string
+		From     time.Time
+		Expected time.Time
+	}{
+		{Duration: "PT15M", From: day.Add(10*time.Hour + 7*time.Minute), Expected: day.Add(10*time.Hour + 15*time.Minute)},
+		{Duration: "PT15M", From: day.Add(10*time.Hour + 15*time.Minute), Expected: day.Add(10*time.Hour + 30*time.Minute)},
+		{Duration: "PT1H", From: day.Add(23*time.Hour + time.Nanosecond), Expected: day.Add(24 * time.Hour)},
+		{Duration: "-PT1H", From: day.Add(90 * time.Minute), Expected: day.Add(2 * time.Hour)},
+		{Duration: "PT7M", From: time.Unix(0, 0).Add(-time.Minute), Expected: time.Unix(0, 0)},
+		{Duration: "P1D", From: day.Add(time.Hour), Expected: day.Add(25 * time.Hour)},
+		{Duration: "PT0S", From: day, Expected: day},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.NextAligned(c.From); !got.Equal(c.Expected) {
+			t.Fatalf("expected %s after %s to align to %s; got %s", c.Duration, c.From, c.Expected, got)
+		}
+	}
+
+	d, _ := ParseDuration("PT15M")
+	anchor := day.Add(5 * time.Minute)
+	if got := d.NextAlignedTo(day.Add(10*time.Hour+7*time.Minute), anchor); !got.Equal(day.Add(10*time.Hour + 20*time.Minute)) {
+		t.Fatalf("expected alignment to the anchor at 10:20; got %s", got)
+	}
+}
+
+func TestDuration_CompareOn(t *testing.T) {
+	cases := []struct {
+		Left      string
+		Right     string
+		Reference time.Time
+		Expected  int
+	}{
+		{
+			Left:      "P1M",
+			Right:     "P30D",
+			Reference: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  -1,
+		},
+		{
+			Left:      "P1M",
+			Right:     "P30D",
+			Reference: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  1,
+		},
+		{
+			Left:      "P1M",
+			Right:     "P30D",
+			Reference: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  0,
+		},
+		{
+			Left:      "P1Y",
+			Right:     "P365D",
+			Reference: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Left+" "+c.Right, func(t *testing.T) {
+			left, _ := ParseDuration(c.Left)
+			right, _ := ParseDuration(c.Right)
+
+			if got := left.CompareOn(c.Reference, right); got != c.Expected {
+				t.Fatalf("expected %d; got %d", c.Expected, got)
+			}
+		})
+	}
+}
+
+func TestDuration_SubOn(t *testing.T) {
+	cases := []struct {
+		Left      string
+		Right     string
+		Reference time.Time
+		Expected  string
+	}{
+		{
+			Left:      "PT1M",
+			Right:     "PT30S",
+			Reference: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  "PT30S",
+		},
+		{
+			Left:      "P1M",
+			Right:     "P1D",
+			Reference: time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  "P27D",
+		},
+		{
+			Left:      "P1M",
+			Right:     "P1D",
+			Reference: time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  "P29D",
+		},
+		{
+			Left:      "P1D",
+			Right:     "P1M",
+			Reference: time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  "-P29D",
+		},
+		{
+			Left:      "P1Y2M3DT4H",
+			Right:     "PT1H",
+			Reference: time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  "P1Y2M3DT3H",
+		},
+		{
+			Left:      "P1D",
+			Right:     "PT24H",
+			Reference: time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  "PT0S",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Left+" "+c.Right, func(t *testing.T) {
+			left, _ := ParseDuration(c.Left)
+			right, _ := ParseDuration(c.Right)
+
+			got := left.SubOn(c.Reference, right)
+			if got.String() != c.Expected {
+				t.Fatalf("expected duration %s; got %s", c.Expected, got)
+			}
+
+			if !got.AddTo(right.AddTo(c.Reference)).Equal(left.AddTo(c.Reference)) {
+				t.Fatalf("expected %s applied after %s to land on %s", got, c.Right, c.Left)
+			}
+		})
+	}
+}
+
+func TestDurationBetween(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Start    time.Time
+		End      time.Time
+		Expected string
+	}{
+		{
+			Name:     "equal",
+			Start:    time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Expected: "PT0S",
+		},
+		{
+			Name:     "full",
+			Start:    time.Date(2021, time.March, 10, 8, 0, 0, 0, time.UTC),
+			End:      time.Date(2024, time.May, 14, 20, 30, 5, 500000000, time.UTC),
+			Expected: "P3Y2M4DT12H30M5.5S",
+		},
+		{
+			Name:     "leap day to next year",
+			Start:    time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC),
+			Expected: "P11M30D",
+		},
+		{
+			Name:     "leap day to leap day",
+			Start:    time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+			Expected: "P4Y",
+		},
+		{
+			Name:     "across leap day",
+			Start:    time.Date(2024, time.February, 28, 12, 0, 0, 0, time.UTC),
+			End:      time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Expected: "P1DT12H",
+		},
+		{
+			Name:     "month end",
+			Start:    time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Expected: "P29D",
+		},
+		{
+			Name:     "month end to month end",
+			Start:    time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC),
+			Expected: "P2M",
+		},
+		{
+			Name:     "clock before start clock",
+			Start:    time.Date(2023, time.January, 15, 18, 0, 0, 0, time.UTC),
+			End:      time.Date(2023, time.February, 15, 6, 0, 0, 0, time.UTC),
+			Expected: "P30DT12H",
+		},
+		{
+			Name:     "negative",
+			Start:    time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+			Expected: "-P1M3D",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			got := DurationBetween(c.Start, c.End)
+			if got.String() != c.Expected {
+				t.Fatalf("expected duration %s; got %s", c.Expected, got)
+			}
+
+			if landed := got.AddTo(c.Start); !landed.Equal(c.End) {
+				t.Fatalf("expected %s applied to %s to land on %s; got %s", got, c.Start, c.End, landed)
+			}
+		})
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	day := time.Date(2024, time.February, 28, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		Name     string
+		Start1   time.Time
+		D1       string
+		Start2   time.Time
+		D2       string
+		Expected string
+		Overlaps bool
+	}{
+		{Name: "partial", Start1: day, D1: "PT2H", Start2: day.Add(time.Hour), D2: "PT2H", Expected: "PT1H", Overlaps: true},
+		{Name: "contained", Start1: day, D1: "P1D", Start2: day.Add(time.Hour), D2: "PT30M", Expected: "PT30M", Overlaps: true},
+		{Name: "calendar aware", Start1: day, D1: "P2D", Start2: day.Add(24 * time.Hour), D2: "P1M", Expected: "PT24H", Overlaps: true},
+		{Name: "negative duration", Start1: day.Add(2 * time.Hour), D1: "-PT2H", Start2: day.Add(time.Hour), D2: "PT2H", Expected: "PT1H", Overlaps: true},
+		{Name: "touching", Start1: day, D1: "PT1H", Start2: day.Add(time.Hour), D2: "PT1H", Expected: "PT0S", Overlaps: false},
+		{Name: "disjoint", Start1: day, D1: "PT1H", Start2: day.Add(3 * time.Hour), D2: "PT1H", Expected: "PT0S", Overlaps: false},
+		{Name: "nil duration", Start1: day, D1: "PT2H", Start2: day.Add(time.Hour), Expected: "PT0S", Overlaps: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			// An empty string fails to parse, leaving the duration nil.
+			d1, _ := ParseDuration(c.D1)
+			d2, _ := ParseDuration(c.D2)
+
+			got, overlaps := Overlap(c.Start1, d1, c.Start2, d2)
+			if got.String() != c.Expected || overlaps != c.Overlaps {
+				t.Fatalf("expected overlap %s, %t; got %s, %t", c.Expected, c.Overlaps, got, overlaps)
+			}
+
+			if swapped, _ := Overlap(c.Start2, d2, c.Start1, d1); swapped.String() != c.Expected {
+				t.Fatalf("expected swapped overlap %s; got %s", c.Expected, swapped)
+			}
+		})
+	}
+}
+
+func TestDuration_TotalDaysFrom(t *testing.T) {
+	cases := []struct {
+		Duration  string
+		Reference time.Time
+		Expected  float64
+	}{
+		{
+			Duration:  "P1M",
+			Reference: time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  28,
+		},
+		{
+			Duration:  "P1M",
+			Reference: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  29,
+		},
+		{
+			Duration:  "P1Y",
+			Reference: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  366,
+		},
+		{
+			Duration:  "P1WT12H",
+			Reference: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  7.5,
+		},
+		{
+			Duration:  "-P1M",
+			Reference: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Expected:  -29,
+		},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.TotalDaysFrom(c.Reference); got != c.Expected {
+			t.Fatalf("expected %s from %s to span %v days; got %v", c.Duration, c.Reference, c.Expected, got)
+		}
+	}
+}
+
+func TestDuration_InMonthsInYears(t *testing.T) {
+	cases := []struct {
+		Duration  string
+		Reference time.Time
+		Months    float64
+		Years     float64
+	}{
+		{
+			Duration:  "P1M",
+			Reference: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			Months:    1,
+			Years:     29.0 / 366,
+		},
+		{
+			Duration:  "P14D",
+			Reference: time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+			Months:    0.5,
+			Years:     14.0 / 365,
+		},
+		{
+			Duration:  "P2M15DT12H",
+			Reference: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Months:    2.5,
+			Years:     (31 + 29 + 15.5) / 366,
+		},
+		{
+			Duration:  "P366D",
+			Reference: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Months:    12 + 1.0/31,
+			Years:     1 + 1.0/366,
+		},
+		{
+			Duration:  "-P1M",
+			Reference: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Months:    -1,
+			Years:     -29.0 / 366,
+		},
+		{
+			Duration:  "PT0S",
+			Reference: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Months:    0,
+			Years:     0,
+		},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.InMonths(c.Reference); math.Abs(got-c.Months) > 1e-12 {
+			t.Fatalf("expected %s from %s to span %v months; got %v", c.Duration, c.Reference, c.Months, got)
+		}
+
+		if got := d.InYears(c.Reference); math.Abs(got-c.Years) > 1e-12 {
+			t.Fatalf("expected %s from %s to span %v years; got %v", c.Duration, c.Reference, c.Years, got)
+		}
+	}
+}
+
+func TestDuration_Range(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2023, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		Duration string
+		Start    time.Time
+		End      time.Time
+		Expected []time.Time
+	}{
+		{
+			Duration: "P1M",
+			Start:    date(time.January, 1),
+			End:      date(time.May, 1),
+			Expected: []time.Time{date(time.January, 1), date(time.February, 1), date(time.March, 1), date(time.April, 1)},
+		},
+		{
+			Duration: "P1M",
+			Start:    date(time.January, 31),
+			End:      date(time.May, 1),
+			Expected: []time.Time{date(time.January, 31), date(time.March, 3), date(time.March, 31)},
+		},
+		{
+			Duration: "PT12H",
+			Start:    date(time.January, 1),
+			End:      date(time.January, 2).Add(time.Second),
+			Expected: []time.Time{date(time.January, 1), date(time.January, 1).Add(time.Hour * 12), date(time.January, 2)},
+		},
+		{
+			Duration: "P1D",
+			Start:    date(time.January, 2),
+			End:      date(time.January, 1),
+		},
+		{
+			Duration: "PT0S",
+			Start:    date(time.January, 1),
+			End:      date(time.January, 2),
+		},
+		{
+			Duration: "-P1D",
+			Start:    date(time.January, 1),
+			End:      date(time.January, 2),
+		},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		got := slices.Collect(d.Range(c.Start, c.End))
+		if !slices.EqualFunc(got, c.Expected, time.Time.Equal) {
+			t.Fatalf("expected %s range to be %v; got %v", c.Duration, c.Expected, got)
+		}
+	}
+
+	d, _ := ParseDuration("P1D")
+	for range d.Range(date(time.January, 1), date(time.December, 1)) {
+		break
+	}
+}
+
+func TestDuration_AddBusinessDays(t *testing.T) {
+	// January 5, 2023 is a Thursday.
+	thursday := time.Date(2023, time.January, 5, 9, 0, 0, 0, time.UTC)
+	saturday := time.Date(2023, time.January, 7, 9, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		Duration string
+		Start    time.Time
+		Expected time.Time
+	}{
+		{Duration: "P1D", Start: thursday, Expected: time.Date(2023, time.January, 6, 9, 0, 0, 0, time.UTC)},
+		{Duration: "P3D", Start: thursday, Expected: time.Date(2023, time.January, 10, 9, 0, 0, 0, time.UTC)},
+		{Duration: "P10D", Start: thursday, Expected: time.Date(2023, time.January, 19, 9, 0, 0, 0, time.UTC)},
+		{Duration: "P1D", Start: saturday, Expected: time.Date(2023, time.January, 9, 9, 0, 0, 0, time.UTC)},
+		{Duration: "-P1D", Start: time.Date(2023, time.January, 9, 9, 0, 0, 0, time.UTC), Expected: time.Date(2023, time.January, 6, 9, 0, 0, 0, time.UTC)},
+		{Duration: "P1W1DT2H", Start: thursday, Expected: time.Date(2023, time.January, 13, 11, 0, 0, 0, time.UTC)},
+		{Duration: "PT2H", Start: saturday, Expected: time.Date(2023, time.January, 7, 11, 0, 0, 0, time.UTC)},
+		{Duration: "P1M1D", Start: thursday, Expected: time.Date(2023, time.February, 6, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.AddBusinessDays(c.Start); !got.Equal(c.Expected) {
+			t.Fatalf("expected %s added to %s to be %s; got %s", c.Duration, c.Start, c.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package durago
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const compactAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// EncodeCompact returns a short URL-safe base62 token of the nanoseconds with the sign in the lowest bit.
+// Only the time.Duration value is kept, so years and months are collapsed with their approximate lengths
+// and DecodeCompact restores the components the way FromTimeDuration does.
+func (d *Duration) EncodeCompact() string {
+	v := uint64(d.d) << 1
+	if d.negative && d.d != 0 {
+		v |= 1
+	}
+
+	if v == 0 {
+		return compactAlphabet[:1]
+	}
+
+	var buf [11]byte
+
+	i := len(buf)
+	for v > 0 {
+		i--
+		buf[i] = compactAlphabet[v%62]
+		v /= 62
+	}
+
+	return string(buf[i:])
+}
+
+// DecodeCompact converts a token returned by EncodeCompact back into a *Duration.
+func DecodeCompact(s string) (*Duration, error) {
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty compact duration", ErrInvalidFormat)
+	}
+
+	var v uint64
+	for _, char := range s {
+		i := strings.IndexRune(compactAlphabet, char)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: unexpected compact character %q", ErrInvalidFormat, char)
+		}
+
+		if v > (1<<64-1-uint64(i))/62 {
+			return nil, fmt.Errorf("compact %w", ErrOverflow)
+		}
+
+		v = v*62 + uint64(i)
+	}
+
+	d := time.Duration(v >> 1)
+	if v&1 == 1 {
+		d = -d
+	}
+
+	return FromTimeDuration(d), nil
+}
+
+// SortKey returns a fixed-width key of 16 lowercase hex digits that sorts lexicographically in the same order
+// as the signed nanoseconds. The nanoseconds are stored in offset binary, i.e. with the sign bit flipped,
+// so e.g. PT0S becomes 8000000000000000. Like EncodeCompact only the time.Duration value is kept.
+func (d *Duration) SortKey() string {
+	return fmt.Sprintf("%016x", uint64(d.GetTimeDuration())^(1<<63))
+}
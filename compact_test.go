@@ -0,0 +1,93 @@
+package durago
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDuration_EncodeCompact(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected string
+	}{
+		{Duration: "PT0S", Expected: "0"},
+		{Duration: "-PT0S", Expected: "0"},
+		{Duration: "PT0.000000001S", Expected: "2"},
+		{Duration: "-PT0.000000001S", Expected: "3"},
+		{Duration: "PT1H", Expected: "22l7aFO4"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.EncodeCompact(); got != c.Expected {
+			t.Fatalf("expected %s to encode to %s; got %s", c.Duration, c.Expected, got)
+		}
+	}
+}
+
+func TestDecodeCompact(t *testing.T) {
+	for _, c := range []string{"PT0S", "PT1H", "-P1DT2H3M4.5S", "P2W", "-PT0.000000001S"} {
+		d, _ := ParseDuration(c)
+
+		got, err := DecodeCompact(d.EncodeCompact())
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+
+		if got.String() != c {
+			t.Fatalf("expected round trip of %s; got %s", c, got)
+		}
+	}
+
+	largest := MaxDuration()
+	got, err := DecodeCompact(largest.EncodeCompact())
+	if err != nil || got.GetTimeDuration() != largest.GetTimeDuration() {
+		t.Fatalf("expected round trip of %s; got %v, %v", largest, got, err)
+	}
+
+	got, err = DecodeCompact("LygHa16AHYF")
+	if err != nil || got.GetTimeDuration() != -largest.GetTimeDuration() {
+		t.Fatalf("expected largest token to decode to -%s; got %v, %v", largest, got, err)
+	}
+
+	for _, c := range []string{"", "a-b", "zzzzzzzzzzzz", "LygHa16AHYG"} {
+		if _, err := DecodeCompact(c); !errors.Is(err, ErrInvalidFormat) && !errors.Is(err, ErrOverflow) {
+			t.Fatalf("expected error decoding %q; got %v", c, err)
+		}
+	}
+}
+
+func TestDuration_SortKey(t *testing.T) {
+	inputs := []string{"P292Y", "P1D", "PT1H", "PT1S", "PT0.000000001S", "PT0S", "-PT0.000000001S", "-PT1S", "-PT1H", "-P1D", "-P292Y"}
+
+	var previous string
+	for _, input := range inputs {
+		d, err := ParseDuration(input)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		key := d.SortKey()
+		if len(key) != 16 {
+			t.Fatalf("expected key of %s to have 16 characters; got %s", input, key)
+		}
+
+		if previous != "" && key >= previous {
+			t.Fatalf("expected key of %s to sort before %s; got %s", input, previous, key)
+		}
+
+		previous = key
+	}
+
+	if key := (&Duration{}).SortKey(); key != "8000000000000000" {
+		t.Fatalf("expected zero key 8000000000000000; got %s", key)
+	}
+
+	if key := MaxDuration().SortKey(); key != "ffffffffffffffff" {
+		t.Fatalf("expected max key ffffffffffffffff; got %s", key)
+	}
+}
@@ -0,0 +1,161 @@
+package durago
+
+import (
+	"math"
+	"time"
+)
+
+// Components is a plain breakdown of a Duration, handy for templates and other reflection based consumers.
+// All values are magnitudes, the sign of the duration is held by Negative.
+type Components struct {
+	Years    int
+	Months   int
+	Weeks    int
+	Days     int
+	Hours    int
+	Minutes  int
+	Seconds  float64
+	Negative bool
+}
+
+// Components returns the breakdown of the duration.
+func (d *Duration) Components() Components {
+	return Components{
+		Years:    d.years,
+		Months:   d.months,
+		Weeks:    d.weeks,
+		Days:     d.days,
+		Hours:    d.hours,
+		Minutes:  d.minutes,
+		Seconds:  d.seconds,
+		Negative: d.negative,
+	}
+}
+
+// SignedComponents returns the breakdown of the duration with the sign applied to every field,
+// so -P1DT2H gives Days: -1 and Hours: -2. Components instead returns magnitudes and leaves the sign to Negative.
+// Negative is still set for negative durations, a zero duration has all fields zero and no sign.
+// The result isn't meant for FromComponents, which expects magnitudes.
+func (d *Duration) SignedComponents() Components {
+	c := d.Components()
+	if !d.negative || d.d == 0 {
+		c.Negative = false
+		return c
+	}
+
+	c.Years = -c.Years
+	c.Months = -c.Months
+	c.Weeks = -c.Weeks
+	c.Days = -c.Days
+	c.Hours = -c.Hours
+	c.Minutes = -c.Minutes
+	c.Seconds = -c.Seconds
+
+	return c
+}
+
+// FromComponents builds a *Duration from the given breakdown, it's the inverse of Components.
+// The components are kept as is, use Overflows to check whether they fit into a time.Duration.
+func FromComponents(c Components) *Duration {
+	duration := &Duration{
+		negative: c.Negative,
+		years:    c.Years,
+		months:   c.Months,
+		weeks:    c.Weeks,
+		days:     c.Days,
+		hours:    c.Hours,
+		minutes:  c.Minutes,
+		seconds:  c.Seconds,
+	}
+
+	duration.d = time.Duration(c.Years)*periodYear + time.Duration(c.Months)*periodMonth +
+		time.Duration(c.Weeks)*periodWeek + time.Duration(c.Days)*periodDay +
+		time.Duration(c.Hours)*nsPerHour + time.Duration(c.Minutes)*nsPerMinute +
+		time.Duration(math.Round(c.Seconds*nsPerSecond))
+
+	return duration
+}
+
+// intComponent is one of the integer components of a duration with its unit and approximate length.
+type intComponent struct {
+	unit  Unit
+	value int
+	size  time.Duration
+}
+
+// intComponents returns the integer components from years down to minutes, the seconds are left to the caller.
+func (d *Duration) intComponents() [6]intComponent {
+	return [...]intComponent{
+		{UnitYear, d.years, periodYear},
+		{UnitMonth, d.months, periodMonth},
+		{UnitWeek, d.weeks, periodWeek},
+		{UnitDay, d.days, periodDay},
+		{UnitHour, d.hours, nsPerHour},
+		{UnitMinute, d.minutes, nsPerMinute},
+	}
+}
+
+// Zero is the zero duration PT0S. It's shared, so it must not be modified, e.g. by UnmarshalJSON.
+var Zero = &Duration{}
+
+// Days returns a duration of n days, e.g. P3D, negative n give a negative duration.
+func Days(n int) *Duration {
+	return FromComponents(Components{Days: abs(n), Negative: n < 0})
+}
+
+// Hours returns a duration of n hours, e.g. PT36H, without carrying them into days.
+func Hours(n int) *Duration {
+	return FromComponents(Components{Hours: abs(n), Negative: n < 0})
+}
+
+// Minutes returns a duration of n minutes, e.g. PT90M, without carrying them into hours.
+func Minutes(n int) *Duration {
+	return FromComponents(Components{Minutes: abs(n), Negative: n < 0})
+}
+
+// Seconds returns a duration of f seconds, e.g. PT1.5S, without carrying them into minutes.
+func Seconds(f float64) *Duration {
+	return FromComponents(Components{Seconds: math.Abs(f), Negative: f < 0})
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// IsCalendarOnly reports whether the duration only consists of years, months, weeks or days.
+// It returns false for a zero duration.
+func (d *Duration) IsCalendarOnly() bool {
+	return d.hasCalendar() && !d.hasClock()
+}
+
+// IsClockOnly reports whether the duration only consists of hours, minutes or seconds.
+// It returns false for a zero duration.
+func (d *Duration) IsClockOnly() bool {
+	return d.hasClock() && !d.hasCalendar()
+}
+
+func (d *Duration) hasCalendar() bool {
+	return d.years != 0 || d.months != 0 || d.weeks != 0 || d.days != 0
+}
+
+func (d *Duration) hasClock() bool {
+	return d.hours != 0 || d.minutes != 0 || d.seconds != 0
+}
+
+// WeeksExact returns the number of weeks and true if the duration consists of weeks only, e.g. P4W,
+// the count is negative for negative durations. Any other component or a zero duration returns false.
+func (d *Duration) WeeksExact() (int, bool) {
+	if d.weeks == 0 || d.years != 0 || d.months != 0 || d.days != 0 || d.hasClock() {
+		return 0, false
+	}
+
+	if d.negative {
+		return -d.weeks, true
+	}
+
+	return d.weeks, true
+}
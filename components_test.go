@@ -0,0 +1,182 @@
+package durago
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestDuration_Components(t *testing.T) {
+	d, err := ParseDuration("-P1Y2M3W4DT5H6M7.5S")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	expected := Components{
+		Years:    1,
+		Months:   2,
+		Weeks:    3,
+		Days:     4,
+		Hours:    5,
+		Minutes:  6,
+		Seconds:  7.5,
+		Negative: true,
+	}
+
+	if got := d.Components(); got != expected {
+		t.Fatalf("expected components %+v; got %+v", expected, got)
+	}
+
+	var b strings.Builder
+	tmpl := template.Must(template.New("").Parse("{{.Years}}y {{.Hours}}h {{.Seconds}}s"))
+	if err := tmpl.Execute(&b, d.Components()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if b.String() != "1y 5h 7.5s" {
+		t.Fatalf("expected 1y 5h 7.5s; got %s", b.String())
+	}
+}
+
+func TestFromComponents(t *testing.T) {
+	for _, c := range []string{"-P1Y2M3W4DT5H6M7.5S", "PT0.001S", "P1D", "PT0S"} {
+		t.Run(c, func(t *testing.T) {
+			d, err := ParseDuration(c)
+			if err != nil {
+				t.Fatalf("expected to parse duration; got %v", err)
+			}
+
+			got := FromComponents(d.Components())
+			if !reflect.DeepEqual(got, d) {
+				t.Fatalf("expected duration %#v; got %#v", *d, *got)
+			}
+		})
+	}
+
+	got := FromComponents(Components{Minutes: 90})
+	if got.String() != "PT90M" {
+		t.Fatalf("expected duration PT90M; got %s", got)
+	}
+}
+
+func TestConstructors(t *testing.T) {
+	cases := []struct {
+		Duration *Duration
+		Expected string
+		Time     time.Duration
+	}{
+		{Duration: Zero, Expected: "PT0S", Time: 0},
+		{Duration: Days(3), Expected: "P3D", Time: 3 * timeDay},
+		{Duration: Days(-1), Expected: "-P1D", Time: -timeDay},
+		{Duration: Hours(36), Expected: "PT36H", Time: 36 * time.Hour},
+		{Duration: Minutes(90), Expected: "PT90M", Time: 90 * time.Minute},
+		{Duration: Minutes(0), Expected: "PT0S", Time: 0},
+		{Duration: Seconds(1.5), Expected: "PT1.5S", Time: 1500 * time.Millisecond},
+		{Duration: Seconds(-0.25), Expected: "-PT0.25S", Time: -250 * time.Millisecond},
+	}
+
+	for _, c := range cases {
+		if c.Duration.String() != c.Expected || c.Duration.GetTimeDuration() != c.Time {
+			t.Fatalf("expected %s of %d; got %s of %d", c.Expected, c.Time, c.Duration, c.Duration.GetTimeDuration())
+		}
+
+		parsed, err := ParseDuration(c.Expected)
+		if err != nil || parsed.String() != c.Duration.String() {
+			t.Fatalf("expected %s to parse back; got %v, %v", c.Expected, parsed, err)
+		}
+	}
+}
+
+func TestDuration_IsCalendarOnly_IsClockOnly(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Calendar bool
+		Clock    bool
+	}{
+		{Duration: "P1Y2M3W4D", Calendar: true},
+		{Duration: "-P1D", Calendar: true},
+		{Duration: "P1DT0S", Calendar: true},
+		{Duration: "PT1H2M3.5S", Clock: true},
+		{Duration: "P0DT1S", Clock: true},
+		{Duration: "P1DT1H"},
+		{Duration: "P1YT0.5S"},
+		{Duration: "PT0S"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.IsCalendarOnly(); got != c.Calendar {
+			t.Fatalf("expected %s calendar only to be %t; got %t", c.Duration, c.Calendar, got)
+		}
+
+		if got := d.IsClockOnly(); got != c.Clock {
+			t.Fatalf("expected %s clock only to be %t; got %t", c.Duration, c.Clock, got)
+		}
+	}
+}
+
+func TestDuration_WeeksExact(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Weeks    int
+		Ok       bool
+	}{
+		{Duration: "P4W", Weeks: 4, Ok: true},
+		{Duration: "-P2W", Weeks: -2, Ok: true},
+		{Duration: "P0Y1WT0S", Weeks: 1, Ok: true},
+		{Duration: "P7D"},
+		{Duration: "P1W1D"},
+		{Duration: "P1M1W"},
+		{Duration: "P1WT1S"},
+		{Duration: "PT0S"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		weeks, ok := d.WeeksExact()
+		if weeks != c.Weeks || ok != c.Ok {
+			t.Fatalf("expected %s to be (%d, %t); got (%d, %t)", c.Duration, c.Weeks, c.Ok, weeks, ok)
+		}
+	}
+}
+
+func TestDuration_SignedComponents(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected Components
+	}{
+		{
+			Duration: "-P1Y2M3W4DT5H6M7.5S",
+			Expected: Components{Years: -1, Months: -2, Weeks: -3, Days: -4, Hours: -5, Minutes: -6, Seconds: -7.5, Negative: true},
+		},
+		{
+			Duration: "P1DT2H",
+			Expected: Components{Days: 1, Hours: 2},
+		},
+		{
+			Duration: "-P0D",
+			Expected: Components{},
+		},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.SignedComponents(); got != c.Expected {
+			t.Fatalf("expected %s components %+v; got %+v", c.Duration, c.Expected, got)
+		}
+	}
+}
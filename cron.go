@@ -0,0 +1,50 @@
+package durago
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var ErrCron = errors.New("cron conversion failed")
+
+// ToCron returns a best-effort cron expression describing a recurrence every d.
+// Only durations made of a single minute, hour or day component that evenly divides
+// its parent unit are supported, e.g. PT15M, PT6H or P1D. Any other duration returns an error.
+func (d *Duration) ToCron() (string, error) {
+	if d.negative {
+		return "", fmt.Errorf("%w: negative duration", ErrCron)
+	}
+
+	if d.years != 0 || d.months != 0 || d.weeks != 0 || d.seconds != 0 {
+		return "", fmt.Errorf("%w: only minutes, hours or days are supported", ErrCron)
+	}
+
+	switch {
+	case d.minutes != 0 && d.hours == 0 && d.days == 0:
+		if d.minutes >= 60 || 60%d.minutes != 0 {
+			return "", fmt.Errorf("%w: minutes must evenly divide an hour", ErrCron)
+		}
+
+		return cronStep(d.minutes) + " * * * *", nil
+	case d.hours != 0 && d.minutes == 0 && d.days == 0:
+		if d.hours >= 24 || 24%d.hours != 0 {
+			return "", fmt.Errorf("%w: hours must evenly divide a day", ErrCron)
+		}
+
+		return "0 " + cronStep(d.hours) + " * * *", nil
+	case d.days == 1 && d.hours == 0 && d.minutes == 0:
+		// Day of month steps restart on every month, so only a daily recurrence maps cleanly.
+		return "0 0 * * *", nil
+	}
+
+	return "", fmt.Errorf("%w: duration must consist of a single component", ErrCron)
+}
+
+func cronStep(n int) string {
+	if n == 1 {
+		return "*"
+	}
+
+	return "*/" + strconv.Itoa(n)
+}
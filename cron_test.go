@@ -0,0 +1,55 @@
+package durago
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDuration_ToCron(t *testing.T) {
+	cases := []struct {
+		Duration    string
+		Expected    string
+		ExpectedErr bool
+	}{
+		{Duration: "PT1M", Expected: "* * * * *"},
+		{Duration: "PT15M", Expected: "*/15 * * * *"},
+		{Duration: "PT1H", Expected: "0 * * * *"},
+		{Duration: "PT6H", Expected: "0 */6 * * *"},
+		{Duration: "P1D", Expected: "0 0 * * *"},
+		{Duration: "P0DT15M", Expected: "*/15 * * * *"},
+		{Duration: "PT7M", ExpectedErr: true},
+		{Duration: "PT90M", ExpectedErr: true},
+		{Duration: "PT5H", ExpectedErr: true},
+		{Duration: "P2D", ExpectedErr: true},
+		{Duration: "PT1H30M", ExpectedErr: true},
+		{Duration: "PT30S", ExpectedErr: true},
+		{Duration: "P1Y2M3D", ExpectedErr: true},
+		{Duration: "-PT15M", ExpectedErr: true},
+		{Duration: "PT0S", ExpectedErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Duration, func(t *testing.T) {
+			d, err := ParseDuration(c.Duration)
+			if err != nil {
+				t.Fatalf("expected to parse duration; got %v", err)
+			}
+
+			got, err := d.ToCron()
+			if c.ExpectedErr {
+				if !errors.Is(err, ErrCron) {
+					t.Fatalf("expected cron error; got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+
+			if got != c.Expected {
+				t.Fatalf("expected cron %s; got %s", c.Expected, got)
+			}
+		})
+	}
+}
@@ -1,13 +1,20 @@
 package durago
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 )
 
 const (
@@ -32,19 +39,120 @@ const (
 	weekDesignator        = 'W'
 	yearDesignator        = 'Y'
 	durationDesignator    = 'P'
+	microDesignator       = 'U'
+	nanoDesignator        = 'N'
 
 	positiveSign    = '+'
 	negativeSign    = '-'
 	floatDesignator = '.'
+	digitSeparator  = '_'
 
 	zeroDuration = "PT0S"
+
+	// maxComponentDigits is the number of digits a component may have in strict mode, every
+	// 18 digit integer fits into an int64.
+	maxComponentDigits = 18
+
+	maxDuration time.Duration = math.MaxInt64
+)
+
+// parseMode is a set of flags altering the behavior of parseDuration.
+type parseMode uint8
+
+const (
+	parseExtended parseMode = 1 << iota
+	parsePrefix
+	parseLenient
+	parseStrict
+	parseReadable
 )
 
 var (
 	ErrInvalidFormat = errors.New("invalid format")
 	ErrParse         = errors.New("parse failed")
+	ErrOverflow      = errors.New("overflow")
+)
+
+// Static parsing errors are created once, so rejecting malformed input only allocates the *ParseError wrapping them.
+var (
+	errUnexpectedPositiveSign = fmt.Errorf("%w: unexpected positive sign", ErrInvalidFormat)
+	errUnexpectedNegativeSign = fmt.Errorf("%w: unexpected negative sign", ErrInvalidFormat)
+	errUnexpectedDuration     = fmt.Errorf("%w: unexpected duration designator", ErrInvalidFormat)
+	errUnexpectedYear         = fmt.Errorf("%w: unexpected year designator", ErrInvalidFormat)
+	errUnexpectedMonth        = fmt.Errorf("%w: unexpected month designator", ErrInvalidFormat)
+	errUnexpectedMinute       = fmt.Errorf("%w: unexpected minute designator", ErrInvalidFormat)
+	errUnexpectedWeek         = fmt.Errorf("%w: unexpected week designator", ErrInvalidFormat)
+	errUnexpectedDay          = fmt.Errorf("%w: unexpected day designator", ErrInvalidFormat)
+	errUnexpectedTime         = fmt.Errorf("%w: unexpected time designator", ErrInvalidFormat)
+	errUnexpectedHour         = fmt.Errorf("%w: unexpected hour designator", ErrInvalidFormat)
+	errUnexpectedSecond       = fmt.Errorf("%w: unexpected second designator", ErrInvalidFormat)
+	errUnexpectedSubSecond    = fmt.Errorf("%w: unexpected sub-second designator", ErrInvalidFormat)
+	errUnexpectedValue        = fmt.Errorf("%w: unexpected value or designator", ErrInvalidFormat)
+	errMalformedNumber        = fmt.Errorf("%w: malformed number", ErrInvalidFormat)
+	errHourWithoutTime        = fmt.Errorf("%w: hour designator requires a preceding time designator (T)", ErrInvalidFormat)
+	errSecondWithoutTime      = fmt.Errorf("%w: second designator requires a preceding time designator (T)", ErrInvalidFormat)
+	errMissingDesignator      = fmt.Errorf("%w: missing designator", ErrInvalidFormat)
+	errEmptyTimeSection       = fmt.Errorf("%w: empty time section", ErrInvalidFormat)
+	errNoComponents           = fmt.Errorf("%w: no duration components", ErrInvalidFormat)
+	errMissingPeriod          = fmt.Errorf("%w: missing period designator (P)", ErrInvalidFormat)
+	errMinutesOutOfRange      = fmt.Errorf("%w: minutes out of range", ErrInvalidFormat)
+	errSecondsOutOfRange      = fmt.Errorf("%w: seconds out of range", ErrInvalidFormat)
+	errNumberTooLong          = fmt.Errorf("%w: numeric field too long", ErrInvalidFormat)
 )
 
+// ParseError describes a failure to parse a duration. Kind is one of ErrInvalidFormat, ErrParse or ErrOverflow
+// and Pos is the byte offset in Input at which the problem was detected. The message is the one of the
+// underlying error, e.g. "invalid format: missing designator", and errors.Is matches Kind.
+// Input is always the string given by the caller, also for ParseDurationRelaxed, ParseDurationSigned
+// and ParseRecurring, which parse only a part of it.
+type ParseError struct {
+	Input string
+	Pos   int
+	Kind  error
+
+	err error
+}
+
+func newParseError(input string, pos int, err error) *ParseError {
+	kind := ErrInvalidFormat
+	switch {
+	case errors.Is(err, ErrOverflow):
+		kind = ErrOverflow
+	case errors.Is(err, ErrParse):
+		kind = ErrParse
+	}
+
+	return &ParseError{Input: input, Pos: pos, Kind: kind, err: err}
+}
+
+// rebaseParseError makes a *ParseError returned for a part of input, e.g. after stripping a marker,
+// report input itself, with pos mapping the position in the part to the one in input.
+func rebaseParseError(err error, input string, pos func(int) int) error {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		pe.Input = input
+		pe.Pos = pos(pe.Pos)
+	}
+
+	return err
+}
+
+func (e *ParseError) Error() string {
+	if e.err == nil {
+		return e.Kind.Error()
+	}
+
+	return e.err.Error()
+}
+
+// Unwrap returns Kind.
+func (e *ParseError) Unwrap() error {
+	return e.Kind
+}
+
+// Duration is an ISO8601 duration. The zero value is ready to use and represents PT0S.
+// Methods only read the receiver and return new values, so a *Duration can be shared between goroutines.
+// UnmarshalJSON, PGInterval.Scan and ParseDurationInto modify the duration and must not run concurrently with other uses of it.
 type Duration struct {
 	d        time.Duration
 	negative bool
@@ -56,161 +164,610 @@ type Duration struct {
 	hours   int
 	minutes int
 	seconds float64
+
+	// zeros has the bit 1<<Unit set for every designator written with a zero value in the parsed input.
+	zeros uint8
 }
 
 // ParseDuration attempts to parse the given duration string into a *Duration,
 // if parsing fails an error is returned instead.
+// Leading and trailing whitespace is ignored, whitespace inside the duration is not.
+// Fractional seconds require digits on both sides of the decimal point, e.g. PT0.5S but not PT.5S or PT5.S.
+// Parsing keeps no shared state, so it and all other parse functions are safe for concurrent use.
+// Errors are returned as *ParseError, which costs one allocation per rejected input in exchange for the
+// position of the problem; the underlying messages are static, so nothing else is allocated.
 func ParseDuration(d string) (*Duration, error) {
+	duration, _, err := parseDuration(d, 0)
+	return duration, err
+}
+
+// ParseDurationsAll parses every input and returns the results and the errors as slices parallel to inputs.
+// A failed element has a nil duration and an error mentioning the original input, a successful one a nil error.
+func ParseDurationsAll(inputs []string) ([]*Duration, []error) {
+	durations := make([]*Duration, len(inputs))
+	errs := make([]error, len(inputs))
+
+	for i, input := range inputs {
+		d, err := ParseDuration(input)
+		if err != nil {
+			errs[i] = fmt.Errorf("%q: %w", input, err)
+			continue
+		}
+
+		durations[i] = d
+	}
+
+	return durations, errs
+}
+
+// ParseDurationOr works like ParseDuration but returns a copy of def if s is empty or only whitespace,
+// a nil def gives PT0S. Invalid non-empty input still returns the parse error.
+func ParseDurationOr(s string, def *Duration) (*Duration, error) {
+	if strings.TrimSpace(s) == "" {
+		duration := new(Duration)
+		if def != nil {
+			*duration = *def
+		}
+
+		return duration, nil
+	}
+
+	return ParseDuration(s)
+}
+
+// ParseDurationEnv parses the first of the given environment variables that is set to a non-blank value,
+// so keys are tried in order of precedence, e.g. PRIMARY_TIMEOUT before TIMEOUT. If none is set it returns nil, nil,
+// an invalid value returns the parse error mentioning the variable without trying the remaining keys.
+func ParseDurationEnv(keys ...string) (*Duration, error) {
+	for _, key := range keys {
+		value := os.Getenv(key)
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		d, err := ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+
+		return d, nil
+	}
+
+	return nil, nil
+}
+
+// ParseDurationExtended works like ParseDuration but additionally accepts the non-standard
+// sub-second designators MS, US and NS after the seconds, e.g. PT500MS or PT5S500MS.
+// Sub-second values must be integers and follow the order S, MS, US, NS.
+func ParseDurationExtended(d string) (*Duration, error) {
+	duration, _, err := parseDuration(d, parseExtended)
+	return duration, err
+}
+
+// ParseDurationLenient works like ParseDuration but doesn't require the time designator
+// before hours or seconds, as those designators are unambiguous, e.g. P1H30M is 1 hour and 30 minutes.
+// An M is only treated as minutes once hours or the time designator were seen, so P30M is still 30 months.
+func ParseDurationLenient(d string) (*Duration, error) {
+	duration, _, err := parseDuration(d, parseLenient)
+	return duration, err
+}
+
+// ParseDurationReadable works like ParseDuration but allows underscores between digits for readability,
+// like Go numeric literals, e.g. P1_000D. An underscore must sit between two digits, so P_1D, P1__0D
+// and PT1_.5S are rejected.
+func ParseDurationReadable(d string) (*Duration, error) {
+	duration, _, err := parseDuration(d, parseReadable)
+	return duration, err
+}
+
+// ParseDurationStrict works like ParseDuration but additionally enforces the natural ranges
+// of the clock components: minutes and seconds must be below 60, so PT60S and PT90M are rejected.
+// Hours are unbounded, as ISO8601 allows durations such as PT36H.
+// Every component is limited to 18 digits, so excessively padded input like P0000000000000000001D is rejected.
+func ParseDurationStrict(d string) (*Duration, error) {
+	duration, _, err := parseDuration(d, parseStrict)
+	return duration, err
+}
+
+// ParseDurationRelaxed works like ParseDuration but strips a single leading type marker rune first,
+// as emitted by some systems, e.g. @P1D. The allowed markers are given by prefixes and default to '@'.
+func ParseDurationRelaxed(d string, prefixes ...rune) (*Duration, error) {
+	if len(prefixes) == 0 {
+		prefixes = []rune{'@'}
+	}
+
+	input := d
+	d = strings.TrimLeftFunc(d, unicode.IsSpace)
+	if r, size := utf8.DecodeRuneInString(d); slices.Contains(prefixes, r) {
+		d = d[size:]
+	}
+
+	duration, err := ParseDuration(d)
+	if err != nil {
+		offset := len(input) - len(d)
+		return nil, rebaseParseError(err, input, func(pos int) int { return pos + offset })
+	}
+
+	return duration, nil
+}
+
+// ParseDurationPrefix parses the ISO8601 duration at the start of s and returns it together with
+// the remaining input, which begins at the first character that isn't part of the duration.
+// Leading whitespace is ignored. Digits without a designator and designators without digits are left
+// in the remaining input, and input that doesn't start with a duration returns an error.
+func ParseDurationPrefix(s string) (*Duration, string, error) {
+	return parseDuration(s, parsePrefix)
+}
+
+func parseDuration(d string, mode parseMode) (*Duration, string, error) {
+	// The duration is only moved to the heap once parsing succeeds.
+	var duration Duration
+
+	rest, pos, err := parseDurationInto(&duration, d, mode)
+	if err != nil {
+		return nil, "", newParseError(d, pos, err)
+	}
+
+	result := new(Duration)
+	*result = duration
+
+	return result, rest, nil
+}
+
+// parseDurationInto parses d and stores the result in dst, which is left untouched if parsing fails.
+// On failure it returns the byte offset in d at which the problem was detected.
+func parseDurationInto(dst *Duration, d string, mode parseMode) (string, int, error) {
 	// We track the last parsed element to make sure the designators are in the correct order.
 	var lastParsed int8 = -1
 
+	var duration Duration
+	period := false
+	// secondsNs are the exact seconds and sub-seconds, the float seconds are derived from them once.
+	var secondsNs time.Duration
+
 	state := stateParsePeriod
-	duration := &Duration{}
 	num := make([]rune, 0, 4)
+	numStart := 0
+	skip := false
+
+	// timeStart is the index of the time designator and beforeTime the element parsed before it,
+	// so prefix mode can leave a time designator without components in the remaining input.
+	timeStart := 0
+	var beforeTime int8
+
+	// offset is the length of the trimmed leading whitespace, pos the offset of the current character.
+	offset := len(d) - len(strings.TrimLeftFunc(d, unicode.IsSpace))
+	pos := 0
+
+	if mode&parsePrefix != 0 {
+		d = strings.TrimLeftFunc(d, unicode.IsSpace)
+	} else {
+		d = strings.TrimSpace(d)
+	}
+
+	// end is the index of the first character that isn't part of the duration in prefix mode.
+	end := len(d)
+
+loop:
+	for i, char := range d {
+		pos = i
+
+		if skip {
+			skip = false
+			continue
+		}
+
+		// A decimal point must be followed by at least one digit.
+		if len(num) > 0 && num[len(num)-1] == floatDesignator && !isDigit(char) {
+			if mode&parsePrefix != 0 {
+				end = numStart
+				break loop
+			}
+
+			return "", offset + pos, errMalformedNumber
+		}
+
+		if mode&parseExtended != 0 && state == stateParseTime && isSubSecondDesignator(char) && strings.HasPrefix(d[i+1:], string(secondDesignator)) {
+			unit, level := subSecondUnit(char)
+			if lastParsed >= level {
+				return "", offset + pos, errUnexpectedSubSecond
+			}
+
+			value, err := duration.addComponent(num, unit, "sub-second")
+			if err != nil {
+				return "", offset + pos, err
+			}
+
+			lastParsed = level
+			duration.zeros |= 1 << UnitSecond
+			num = num[:0]
+			skip = true
+			secondsNs += time.Duration(value) * unit
+			duration.seconds = float64(secondsNs) / nsPerSecond
+			continue
+		}
+
+		// In prefix mode anything that can't continue the duration ends it instead of failing: a designator
+		// without a value, e.g. the M in PT1HMORE, a sign after the start, a second P or T and a misplaced decimal point.
+		if mode&parsePrefix != 0 {
+			var stop bool
+			switch char {
+			case positiveSign, negativeSign:
+				stop = state != stateParsePeriod || lastParsed >= 0 || len(num) > 0
+			case durationDesignator:
+				stop = state != stateParsePeriod || lastParsed >= 1
+			case timeDesignator:
+				stop = state != stateParsePeriod || lastParsed >= 6
+			case floatDesignator:
+				stop = len(num) == 0 || slices.Contains(num, floatDesignator)
+			default:
+				stop = len(num) == 0 && isValueDesignator(char)
+			}
+
+			if stop {
+				end = i
+				if len(num) > 0 {
+					end = numStart
+				}
+
+				break loop
+			}
+		}
 
-	for _, char := range d {
 		switch char {
 		case positiveSign:
-			if state != stateParsePeriod || lastParsed >= 0 {
-				return nil, fmt.Errorf("%w: unexpected positive sign", ErrInvalidFormat)
+			// A sign is only allowed as the very first character.
+			if state != stateParsePeriod || lastParsed >= 0 || len(num) > 0 {
+				return "", offset + pos, errUnexpectedPositiveSign
 			}
 
 			lastParsed = 0
 		case negativeSign:
-			if state != stateParsePeriod || lastParsed >= 0 {
-				return nil, fmt.Errorf("%w: unexpected negative sign", ErrInvalidFormat)
+			// A sign is only allowed as the very first character.
+			if state != stateParsePeriod || lastParsed >= 0 || len(num) > 0 {
+				return "", offset + pos, errUnexpectedNegativeSign
 			}
 
 			lastParsed = 0
 			duration.negative = true
 		case durationDesignator:
 			if state != stateParsePeriod || lastParsed >= 1 {
-				return nil, fmt.Errorf("%w: unexpected duration designator", ErrInvalidFormat)
+				return "", offset + pos, errUnexpectedDuration
 			}
 			lastParsed = 1
+			period = true
 		case yearDesignator:
 			if state != stateParsePeriod || lastParsed >= 2 {
-				return nil, fmt.Errorf("%w: unexpected year designator", ErrInvalidFormat)
+				return "", offset + pos, errUnexpectedYear
 			}
 
-			years, err := strconv.ParseInt(string(num), 10, 64)
+			years, err := duration.addComponent(num, periodYear, "year")
 			if err != nil {
-				return nil, fmt.Errorf("year %w: %s", ErrParse, err.Error())
+				return "", offset + pos, err
 			}
 
 			lastParsed = 2
+			duration.zeros |= 1 << UnitYear
 			num = num[:0]
-			duration.d += time.Duration(years * periodYear)
-			duration.years = int(years)
+			duration.years = years
 		case minuteMonthDesignator:
 			if state == stateParsePeriod {
 				if lastParsed >= 3 {
-					return nil, fmt.Errorf("%w: unexpected month designator", ErrInvalidFormat)
+					return "", offset + pos, errUnexpectedMonth
 				}
 
-				months, err := strconv.ParseInt(string(num), 10, 64)
+				months, err := duration.addComponent(num, periodMonth, "month")
 				if err != nil {
-					return nil, fmt.Errorf("month %w: %s", ErrParse, err.Error())
+					return "", offset + pos, err
 				}
 
 				lastParsed = 3
+				duration.zeros |= 1 << UnitMonth
 				num = num[:0]
-				duration.d += time.Duration(months * periodMonth)
-				duration.months = int(months)
+				duration.months = months
 				continue
 			}
 
 			if lastParsed >= 8 {
-				return nil, fmt.Errorf("%w: unexpected minute designator", ErrInvalidFormat)
+				return "", offset + pos, errUnexpectedMinute
 			}
 
-			minutes, err := strconv.ParseInt(string(num), 10, 64)
+			minutes, err := duration.addComponent(num, nsPerMinute, "minute")
 			if err != nil {
-				return nil, fmt.Errorf("month %w: %s", ErrParse, err.Error())
+				return "", offset + pos, err
 			}
 
 			lastParsed = 8
+			duration.zeros |= 1 << UnitMinute
 			num = num[:0]
-			duration.d += time.Duration(minutes * nsPerMinute)
-			duration.minutes = int(minutes)
+			duration.minutes = minutes
 		case weekDesignator:
 			if state != stateParsePeriod || lastParsed >= 4 {
-				return nil, fmt.Errorf("%w: unexpected week designator", ErrInvalidFormat)
+				return "", offset + pos, errUnexpectedWeek
 			}
 
-			weeks, err := strconv.ParseInt(string(num), 10, 64)
+			weeks, err := duration.addComponent(num, periodWeek, "week")
 			if err != nil {
-				return nil, fmt.Errorf("week %w: %s", ErrParse, err.Error())
+				return "", offset + pos, err
 			}
 
 			lastParsed = 4
+			duration.zeros |= 1 << UnitWeek
 			num = num[:0]
-			duration.d += time.Duration(weeks * periodWeek)
-			duration.weeks = int(weeks)
+			duration.weeks = weeks
 		case dayDesignator:
 			if state != stateParsePeriod || lastParsed >= 5 {
-				return nil, fmt.Errorf("%w: unexpected day designator", ErrInvalidFormat)
+				return "", offset + pos, errUnexpectedDay
 			}
 
-			days, err := strconv.ParseInt(string(num), 10, 64)
+			days, err := duration.addComponent(num, periodDay, "day")
 			if err != nil {
-				return nil, fmt.Errorf("day %w: %s", ErrParse, err.Error())
+				return "", offset + pos, err
 			}
 
 			lastParsed = 5
+			duration.zeros |= 1 << UnitDay
 			num = num[:0]
-			duration.d += time.Duration(days * periodDay)
-			duration.days = int(days)
+			duration.days = days
 		case timeDesignator:
 			if state != stateParsePeriod || lastParsed >= 6 {
-				return nil, fmt.Errorf("%w: unexpected time designator", ErrInvalidFormat)
+				return "", offset + pos, errUnexpectedTime
 			}
 
+			timeStart, beforeTime = i, lastParsed
 			lastParsed = 6
 			state = stateParseTime
 		case hourDesignator:
-			if state != stateParseTime || lastParsed >= 7 {
-				return nil, fmt.Errorf("%w: unexpected hour designator", ErrInvalidFormat)
+			if mode&parseLenient != 0 && state == stateParsePeriod && lastParsed < 6 {
+				state = stateParseTime
 			}
 
-			hours, err := strconv.ParseInt(string(num), 10, 64)
+			if state == stateParsePeriod {
+				return "", offset + pos, errHourWithoutTime
+			}
+
+			if lastParsed >= 7 {
+				return "", offset + pos, errUnexpectedHour
+			}
+
+			hours, err := duration.addComponent(num, nsPerHour, "hour")
 			if err != nil {
-				return nil, fmt.Errorf("hour %w: %s", ErrParse, err.Error())
+				return "", offset + pos, err
 			}
 
 			lastParsed = 7
+			duration.zeros |= 1 << UnitHour
 			num = num[:0]
-			duration.d += time.Duration(hours * nsPerHour)
-			duration.hours = int(hours)
+			duration.hours = hours
 		case secondDesignator:
-			if state != stateParseTime || lastParsed == 9 {
-				return nil, fmt.Errorf("%w: unexpected second designator", ErrInvalidFormat)
+			if mode&parseLenient != 0 && state == stateParsePeriod && lastParsed < 6 {
+				state = stateParseTime
+			}
+
+			if state == stateParsePeriod {
+				return "", offset + pos, errSecondWithoutTime
+			}
+
+			if lastParsed >= 9 {
+				return "", offset + pos, errUnexpectedSecond
 			}
 
-			seconds, err := strconv.ParseFloat(string(num), 64)
+			seconds, ns, err := parseSeconds(string(num))
 			if err != nil {
-				return nil, fmt.Errorf("second %w: %s", ErrParse, err.Error())
+				if errors.Is(err, strconv.ErrRange) {
+					return "", offset + pos, fmt.Errorf("second %w", ErrOverflow)
+				}
+
+				return "", offset + pos, fmt.Errorf("second %w: %s", ErrParse, err.Error())
+			}
+
+			if ns > maxDuration-duration.d {
+				return "", offset + pos, fmt.Errorf("second %w", ErrOverflow)
 			}
 
 			lastParsed = 9
+			duration.zeros |= 1 << UnitSecond
 			num = num[:0]
-			duration.d += time.Duration(seconds * nsPerSecond)
+			duration.d += ns
 			duration.seconds = seconds
+			secondsNs = ns
 		default:
-			if unicode.IsNumber(char) || char == floatDesignator {
+			if char == floatDesignator {
+				// A decimal point must be preceded by at least one digit and may appear only once.
+				if len(num) == 0 || slices.Contains(num, floatDesignator) {
+					return "", offset + pos, errMalformedNumber
+				}
+
 				num = append(num, char)
 				continue
 			}
 
-			return nil, fmt.Errorf("%w: unexpected value or designator", ErrInvalidFormat)
+			if char == digitSeparator && mode&parseReadable != 0 {
+				if len(num) == 0 || !isDigit(num[len(num)-1]) || i+1 >= len(d) || !isDigit(rune(d[i+1])) {
+					return "", offset + pos, errMalformedNumber
+				}
+
+				continue
+			}
+
+			if isDigit(char) {
+				if len(num) == 0 {
+					numStart = i
+				}
+
+				// Capping the digits rejects absurd input, such as excessive zero-padding, before strconv sees it.
+				if mode&parseStrict != 0 && len(num) >= maxComponentDigits && (len(num) > maxComponentDigits || !slices.Contains(num, floatDesignator)) {
+					return "", offset + pos, errNumberTooLong
+				}
+
+				num = append(num, char)
+				continue
+			}
+
+			// In prefix mode the duration ends at the first character that can't be part of it,
+			// including the digits of a value without a designator.
+			if mode&parsePrefix != 0 {
+				end = i
+				if len(num) > 0 {
+					end = numStart
+				}
+
+				break loop
+			}
+
+			// Only ASCII digits make up numbers, so exponents, hex or digit separators never reach strconv.
+			if len(num) > 0 {
+				return "", offset + pos, errMalformedNumber
+			}
+
+			return "", offset + pos, errUnexpectedValue
 		}
 	}
 
+	pos = len(d)
+
 	if len(num) > 0 {
-		return nil, fmt.Errorf("%w: missing designator", ErrInvalidFormat)
+		if mode&parsePrefix == 0 {
+			return "", offset + pos, errMissingDesignator
+		}
+
+		if end == len(d) {
+			end = numStart
+		}
 	}
 
-	return duration, nil
+	// The time designator must be followed by at least one time component.
+	if state == stateParseTime && lastParsed == 6 {
+		if mode&parsePrefix == 0 {
+			return "", offset + pos, errEmptyTimeSection
+		}
+
+		end, lastParsed = timeStart, beforeTime
+	}
+
+	// Empty input, a sign or a period designator alone, e.g. P or -P, has no components from years through seconds.
+	if lastParsed <= 1 {
+		return "", offset + pos, errNoComponents
+	}
+
+	// The components must be preceded by the period designator, e.g. 1D is rejected.
+	if !period {
+		return "", offset, errMissingPeriod
+	}
+
+	if mode&parseStrict != 0 {
+		if duration.minutes >= 60 {
+			return "", offset + pos, errMinutesOutOfRange
+		}
+
+		if duration.seconds >= 60 {
+			return "", offset + pos, errSecondsOutOfRange
+		}
+	}
+
+	// Only zero-valued designators need to be remembered, a lone zero seconds is the canonical PT0S.
+	duration.zeros &^= duration.nonZeroUnits()
+	if duration.zeros == 1<<UnitSecond && duration.d == 0 {
+		duration.zeros = 0
+	}
+
+	*dst = duration
+
+	return d[end:], 0, nil
+}
+
+// addComponent parses an integer component value and adds it in the given unit to the total,
+// returning ErrOverflow if the total no longer fits into a time.Duration.
+func (d *Duration) addComponent(num []rune, unit time.Duration, name string) (int, error) {
+	value, err := strconv.ParseInt(string(num), 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("%s %w", name, ErrOverflow)
+		}
+
+		return 0, fmt.Errorf("%s %w: %s", name, ErrParse, err.Error())
+	}
+
+	if time.Duration(value) > (maxDuration-d.d)/unit {
+		return 0, fmt.Errorf("%s %w", name, ErrOverflow)
+	}
+
+	d.d += time.Duration(value) * unit
+	return int(value), nil
+}
+
+// parseSeconds parses a decimal seconds value. The nanoseconds are computed from the digits
+// directly instead of the float, so sub-second precision isn't lost to rounding.
+func parseSeconds(num string) (float64, time.Duration, error) {
+	seconds, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	whole, frac, _ := strings.Cut(num, string(floatDesignator))
+
+	var ns time.Duration
+	if whole != "" {
+		w, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if w > int64(maxDuration/nsPerSecond) {
+			return 0, 0, strconv.ErrRange
+		}
+
+		ns = time.Duration(w) * nsPerSecond
+	}
+
+	if len(frac) > 9 {
+		frac = frac[:9]
+	}
+
+	if frac != "" {
+		f, err := strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if ns > maxDuration-time.Duration(f) {
+			return 0, 0, strconv.ErrRange
+		}
+
+		ns += time.Duration(f)
+	}
+
+	return seconds, ns, nil
+}
+
+func isDigit(char rune) bool {
+	return char >= '0' && char <= '9'
+}
+
+// isValueDesignator reports whether the designator follows a value, i.e. isn't P or T.
+func isValueDesignator(char rune) bool {
+	switch char {
+	case yearDesignator, minuteMonthDesignator, weekDesignator, dayDesignator, hourDesignator, secondDesignator:
+		return true
+	}
+
+	return false
+}
+
+func isSubSecondDesignator(char rune) bool {
+	return char == minuteMonthDesignator || char == microDesignator || char == nanoDesignator
+}
+
+// subSecondUnit returns the unit and the ordering level of the extended sub-second designator.
+func subSecondUnit(char rune) (time.Duration, int8) {
+	switch char {
+	case minuteMonthDesignator:
+		return time.Millisecond, 10
+	case microDesignator:
+		return time.Microsecond, 11
+	default:
+		return time.Nanosecond, 12
+	}
 }
 
 // GetTimeDuration returns underlying tim.Duration with corresponding sign
@@ -222,6 +779,21 @@ func (d *Duration) GetTimeDuration() time.Duration {
 	return d.d
 }
 
+// AsInt64Nanos returns the signed number of nanoseconds, the same as int64(d.GetTimeDuration())
+func (d *Duration) AsInt64Nanos() int64 {
+	return int64(d.GetTimeDuration())
+}
+
+// AsFloat64Seconds returns the signed number of seconds, the same as d.GetTimeDuration().Seconds()
+func (d *Duration) AsFloat64Seconds() float64 {
+	return d.GetTimeDuration().Seconds()
+}
+
+// IsZero reports whether the duration is zero, which is also true for the zero value of Duration.
+func (d *Duration) IsZero() bool {
+	return d.d == 0
+}
+
 // FromTimeDuration converts the given time.Duration into durago.Duration.
 func FromTimeDuration(d time.Duration) *Duration {
 	duration := &Duration{}
@@ -272,71 +844,205 @@ func FromTimeDuration(d time.Duration) *Duration {
 	return duration
 }
 
+// FromTimeDurationClock converts the given time.Duration into a durago.Duration made of hours, minutes
+// and seconds only, e.g. 50 hours become PT50H rather than P2DT2H. The result parses back to exactly d.
+func FromTimeDurationClock(d time.Duration) *Duration {
+	if d < 0 {
+		return newDuration(true, 0, 0, 0, 0, -d)
+	}
+
+	return newDuration(false, 0, 0, 0, 0, d)
+}
+
+// PrecisionSafe reports whether the seconds survive the float64 formatting used by String and MarshalJSON,
+// i.e. whether parsing the formatted seconds yields exactly the same nanoseconds.
+// Large seconds with nanosecond fractions such as PT999999999.123456789S aren't precision safe.
+func (d *Duration) PrecisionSafe() bool {
+	_, formatted, err := parseSeconds(strconv.FormatFloat(d.seconds, 'f', -1, 64))
+
+	return err == nil && formatted == d.secondsDuration()
+}
+
+// WholeSeconds returns the integer part of the seconds component, e.g. 1 for PT1.5S.
+// Like the other components it's a magnitude, the sign is held separately.
+func (d *Duration) WholeSeconds() int {
+	return int(d.secondsDuration() / nsPerSecond)
+}
+
+// Nanoseconds returns the fractional part of the seconds component as nanoseconds in the range 0 to 999999999,
+// e.g. 500000000 for PT1.5S. It's exact for parsed input, as it's taken from the nanoseconds rather than the float.
+func (d *Duration) Nanoseconds() int {
+	return int(d.secondsDuration() % nsPerSecond)
+}
+
+// secondsDuration returns the exact nanoseconds of the seconds component.
+func (d *Duration) secondsDuration() time.Duration {
+	return d.clockDuration() - time.Duration(d.hours)*nsPerHour - time.Duration(d.minutes)*nsPerMinute
+}
+
+// MaxDuration returns the largest representable duration, P292Y5M2W5DT21H47M16.854775807S.
+// Parsing anything larger fails with ErrOverflow.
+func MaxDuration() *Duration {
+	return FromTimeDuration(maxDuration)
+}
+
+// Overflows reports whether the components of the duration exceed MaxDuration,
+// in which case the value returned by GetTimeDuration is meaningless.
+func (d *Duration) Overflows() bool {
+	var total time.Duration
+	for _, c := range d.intComponents() {
+		if time.Duration(c.value) > (maxDuration-total)/c.size {
+			return true
+		}
+
+		total += time.Duration(c.value) * c.size
+	}
+
+	return d.seconds*nsPerSecond > float64(maxDuration-total)
+}
+
+// FitsTimeDuration reports whether the sum of the components, which may be negative when built
+// with FromComponents, stays within the int64 nanoseconds of a time.Duration. Unlike the stored
+// total, the sum is recomputed from the components, from years down to seconds, with every step checked for overflow.
+func (d *Duration) FitsTimeDuration() bool {
+	var total time.Duration
+	for _, c := range d.intComponents() {
+		if time.Duration(c.value) > maxDuration/c.size || time.Duration(c.value) < -maxDuration/c.size {
+			return false
+		}
+
+		part := time.Duration(c.value) * c.size
+		if (part > 0 && total > maxDuration-part) || (part < 0 && total < -maxDuration-part) {
+			return false
+		}
+
+		total += part
+	}
+
+	seconds := math.Round(d.seconds * nsPerSecond)
+	if total >= 0 {
+		return seconds <= float64(maxDuration-total) && seconds >= -float64(maxDuration)-float64(total)
+	}
+
+	return seconds >= float64(-maxDuration-total) && seconds <= float64(maxDuration)-float64(total)
+}
+
 // String returns the ISO8601 duration string for the *Duration
 func (d *Duration) String() string {
 	if d.d == 0 {
 		return zeroDuration
 	}
 
-	var (
-		b       strings.Builder
-		hasTime bool
-	)
+	return string(d.Append(make([]byte, 0, 20)))
+}
 
-	b.Grow(20)
+// StringLower returns the ISO8601 duration string with lowercase designators, e.g. p1y2m3dt4h.
+// It only exists for interoperability with systems expecting lowercase, String is the canonical form.
+func (d *Duration) StringLower() string {
+	return strings.ToLower(d.String())
+}
+
+// GoString satisfies the fmt.GoStringer interface by returning the time.Duration representation, e.g. 1h30m0s.
+// It's meant for debugging, years and months are converted with their approximate lengths.
+func (d *Duration) GoString() string {
+	return d.GetTimeDuration().String()
+}
+
+// StringPrec returns the ISO8601 duration string like String, but formats a non-zero seconds component
+// with exactly fractionalDigits digits after the decimal point, e.g. PT1.500S for 3 digits.
+// Zero or negative digits format the seconds as an integer.
+func (d *Duration) StringPrec(fractionalDigits int) string {
+	if d.d == 0 {
+		return zeroDuration
+	}
+
+	return string(d.appendPrec(make([]byte, 0, 20), max(fractionalDigits, 0)))
+}
+
+// Append appends the ISO8601 duration string for the *Duration to b and returns the extended buffer.
+func (d *Duration) Append(b []byte) []byte {
+	return d.appendPrec(b, -1)
+}
+
+// appendPrec appends the ISO8601 duration string formatting seconds with the given precision,
+// -1 uses the smallest number of digits necessary.
+func (d *Duration) appendPrec(b []byte, prec int) []byte {
+	if d.d == 0 {
+		return append(b, zeroDuration...)
+	}
+
+	return d.appendComponents(b, prec, 0)
+}
+
+// appendComponents appends every non-zero component as is, without checking for a zero duration.
+// Components whose bit 1<<Unit is set in keep are appended even if zero.
+func (d *Duration) appendComponents(b []byte, prec int, keep uint8) []byte {
+	var hasTime bool
 
 	if d.negative {
-		b.WriteRune(negativeSign)
+		b = append(b, negativeSign)
 	}
 
-	b.WriteRune(durationDesignator)
+	b = append(b, durationDesignator)
 
-	if d.years != 0 {
-		b.WriteString(strconv.Itoa(d.years))
-		b.WriteRune(yearDesignator)
+	if d.years != 0 || keep&(1<<UnitYear) != 0 {
+		b = strconv.AppendInt(b, int64(d.years), 10)
+		b = append(b, yearDesignator)
 	}
 
-	if d.months != 0 {
-		b.WriteString(strconv.Itoa(d.months))
-		b.WriteRune(minuteMonthDesignator)
+	if d.months != 0 || keep&(1<<UnitMonth) != 0 {
+		b = strconv.AppendInt(b, int64(d.months), 10)
+		b = append(b, minuteMonthDesignator)
 	}
 
-	if d.weeks != 0 {
-		b.WriteString(strconv.Itoa(d.weeks))
-		b.WriteRune(weekDesignator)
+	if d.weeks != 0 || keep&(1<<UnitWeek) != 0 {
+		b = strconv.AppendInt(b, int64(d.weeks), 10)
+		b = append(b, weekDesignator)
 	}
 
-	if d.days != 0 {
-		b.WriteString(strconv.Itoa(d.days))
-		b.WriteRune(dayDesignator)
+	if d.days != 0 || keep&(1<<UnitDay) != 0 {
+		b = strconv.AppendInt(b, int64(d.days), 10)
+		b = append(b, dayDesignator)
 	}
 
-	if d.hours != 0 {
-		b.WriteRune(timeDesignator)
-		b.WriteString(strconv.Itoa(d.hours))
-		b.WriteRune(hourDesignator)
+	if d.hours != 0 || keep&(1<<UnitHour) != 0 {
+		b = append(b, timeDesignator)
+		b = strconv.AppendInt(b, int64(d.hours), 10)
+		b = append(b, hourDesignator)
 		hasTime = true
 	}
 
-	if d.minutes != 0 {
+	if d.minutes != 0 || keep&(1<<UnitMinute) != 0 {
 		if !hasTime {
-			b.WriteRune(timeDesignator)
+			b = append(b, timeDesignator)
 			hasTime = true
 		}
-		b.WriteString(strconv.Itoa(d.minutes))
-		b.WriteRune(minuteMonthDesignator)
+		b = strconv.AppendInt(b, int64(d.minutes), 10)
+		b = append(b, minuteMonthDesignator)
 	}
 
-	if d.seconds != 0 {
+	if d.seconds != 0 || keep&(1<<UnitSecond) != 0 {
 		if !hasTime {
-			b.WriteRune(timeDesignator)
-			hasTime = true
+			b = append(b, timeDesignator)
 		}
-		b.WriteString(strconv.FormatFloat(d.seconds, 'f', -1, 64))
-		b.WriteRune(secondDesignator)
+		b = strconv.AppendFloat(b, d.seconds, 'f', prec, 64)
+		b = append(b, secondDesignator)
 	}
 
-	return b.String()
+	return b
+}
+
+// WriteTo satisfies the io.WriterTo interface by writing the ISO8601 duration string to w
+func (d *Duration) WriteTo(w io.Writer) (int64, error) {
+	var buf [32]byte
+
+	n, err := w.Write(d.Append(buf[:0]))
+	return int64(n), err
+}
+
+// LogValue satisfies the slog.LogValuer interface by logging the ISO8601 duration string
+func (d Duration) LogValue() slog.Value {
+	return slog.StringValue(d.String())
 }
 
 // MarshalJSON satisfies the Marshaler interface by return a valid JSON string representation of the duration
@@ -344,8 +1050,35 @@ func (d Duration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(d.String())
 }
 
-// UnmarshalJSON satisfies the Unmarshaler interface by return a valid JSON string representation of the duration
+// MarshalJSONNanos returns the JSON integer representation of the signed nanoseconds of the duration
+func (d *Duration) MarshalJSONNanos() ([]byte, error) {
+	return strconv.AppendInt(nil, d.AsInt64Nanos(), 10), nil
+}
+
+// UnmarshalJSON satisfies the Unmarshaler interface by parsing either a JSON string holding an ISO8601 duration
+// or a JSON number holding seconds, which is converted with FromTimeDuration. A JSON null resets the duration to zero.
 func (d *Duration) UnmarshalJSON(source []byte) error {
+	source = bytes.TrimSpace(source)
+
+	if string(source) == "null" {
+		*d = Duration{}
+		return nil
+	}
+
+	if len(source) > 0 && source[0] != '"' {
+		var seconds float64
+		if err := json.Unmarshal(source, &seconds); err != nil {
+			return err
+		}
+
+		if math.Abs(seconds) > float64(maxDuration/nsPerSecond) {
+			return fmt.Errorf("second %w", ErrOverflow)
+		}
+
+		*d = *FromTimeDuration(time.Duration(math.Round(seconds * nsPerSecond)))
+		return nil
+	}
+
 	var duration string
 	if err := json.Unmarshal(source, &duration); err != nil {
 		return err
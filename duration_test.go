@@ -1,8 +1,15 @@
 package durago
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -56,41 +63,1149 @@ func TestParseDuration(t *testing.T) {
 			Duration: "P0Y0M0W0DT0H00M05.5S",
 			Expected: time.Second*5 + time.Millisecond*500,
 		},
+		{
+			Name:     "microsecond precision",
+			Duration: "PT1.000001S",
+			Expected: time.Second + time.Microsecond,
+		},
+		{
+			Name:     "nanosecond precision",
+			Duration: "PT0.123456789S",
+			Expected: time.Nanosecond * 123456789,
+		},
+		{
+			Name:     "surrounding spaces",
+			Duration: " PT1H ",
+			Expected: time.Hour,
+		},
+		{
+			Name:     "surrounding tabs",
+			Duration: "\tPT1H\t",
+			Expected: time.Hour,
+		},
+		{
+			Name:     "surrounding newlines",
+			Duration: "\nPT1H\r\n",
+			Expected: time.Hour,
+		},
+		{
+			Name:        "internal whitespace",
+			Duration:    "PT1H 30M",
+			ExpectedErr: "invalid format: unexpected value or designator",
+		},
+		{
+			Name:        "leading decimal point",
+			Duration:    "PT.5S",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "trailing decimal point",
+			Duration:    "PT5.S",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "decimal point only",
+			Duration:    "PT.S",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "multiple decimal points",
+			Duration:    "PT1.5.5S",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "trailing decimal point in period",
+			Duration:    "P1.D",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "exponent",
+			Duration:    "PT1e3S",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "uppercase exponent",
+			Duration:    "PT1E3S",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "hex",
+			Duration:    "PT0x10S",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "digit separator",
+			Duration:    "PT1_000S",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "non ascii digit",
+			Duration:    "PT١S",
+			ExpectedErr: "invalid format: unexpected value or designator",
+		},
 		{
 			Name:        "missing designator",
 			Duration:    "P6",
 			ExpectedErr: "invalid format: missing designator",
 		},
 		{
-			Name:        "missing designator after valid designator in period",
-			Duration:    "P6Y4",
-			ExpectedErr: "invalid format: missing designator",
+			Name:        "missing designator after valid designator in period",
+			Duration:    "P6Y4",
+			ExpectedErr: "invalid format: missing designator",
+		},
+		{
+			Name:        "missing designator after valid designator in time",
+			Duration:    "PT1S12",
+			ExpectedErr: "invalid format: missing designator",
+		},
+		{
+			Name:        "empty time section",
+			Duration:    "P1DT",
+			ExpectedErr: "invalid format: empty time section",
+		},
+		{
+			Name:        "empty time section only",
+			Duration:    "PT",
+			ExpectedErr: "invalid format: empty time section",
+		},
+		{
+			Name:        "period designator only",
+			Duration:    "P",
+			ExpectedErr: "invalid format: no duration components",
+		},
+		{
+			Name:        "sign and period designator only",
+			Duration:    "-P",
+			ExpectedErr: "invalid format: no duration components",
+		},
+		{
+			Name:        "empty input",
+			Duration:    "",
+			ExpectedErr: "invalid format: no duration components",
+		},
+		{
+			Name:        "whitespace only",
+			Duration:    "   ",
+			ExpectedErr: "invalid format: no duration components",
+		},
+		{
+			Name:        "missing period designator",
+			Duration:    "1D",
+			ExpectedErr: "invalid format: missing period designator (P)",
+		},
+		{
+			Name:        "sign without period designator",
+			Duration:    "-1D",
+			ExpectedErr: "invalid format: missing period designator (P)",
+		},
+		{
+			Name:        "unexpected hour designator",
+			Duration:    "PT1S12H",
+			ExpectedErr: "invalid format: unexpected hour designator",
+		},
+		{
+			Name:        "hour without time designator",
+			Duration:    "P1H",
+			ExpectedErr: "invalid format: hour designator requires a preceding time designator (T)",
+		},
+		{
+			Name:        "second without time designator",
+			Duration:    "P1D5S",
+			ExpectedErr: "invalid format: second designator requires a preceding time designator (T)",
+		},
+		{
+			Name:        "unexpected positive sign",
+			Duration:    "P+2Y",
+			ExpectedErr: "invalid format: unexpected positive sign",
+		},
+		{
+			Name:        "negative sign after duration designator",
+			Duration:    "P-1D",
+			ExpectedErr: "invalid format: unexpected negative sign",
+		},
+		{
+			Name:        "negative sign inside value",
+			Duration:    "P1-D",
+			ExpectedErr: "invalid format: unexpected negative sign",
+		},
+		{
+			Name:        "negative sign after value without duration designator",
+			Duration:    "1-D",
+			ExpectedErr: "invalid format: unexpected negative sign",
+		},
+		{
+			Name:        "negative sign in time",
+			Duration:    "PT-1H",
+			ExpectedErr: "invalid format: unexpected negative sign",
+		},
+		{
+			Name:        "trailing negative sign",
+			Duration:    "P1D-",
+			ExpectedErr: "invalid format: unexpected negative sign",
+		},
+		{
+			Name:        "multiple signs",
+			Duration:    "-+P1D",
+			ExpectedErr: "invalid format: unexpected positive sign",
+		},
+		{
+			Name:        "repeated sign",
+			Duration:    "--P1D",
+			ExpectedErr: "invalid format: unexpected negative sign",
+		},
+		{
+			Name:        "duplicate designator",
+			Duration:    "P3Y6M6M2W4DT12H30M5S",
+			ExpectedErr: "invalid format: unexpected month designator",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			d, err := ParseDuration(c.Duration)
+			if err != nil || c.ExpectedErr != "" {
+				if err == nil || err.Error() != c.ExpectedErr {
+					t.Fatalf("expecting error '%s'; got '%v'", c.ExpectedErr, err)
+				}
+				return
+			}
+
+			if c.Expected != d.GetTimeDuration() {
+				t.Fatalf("expected duration %d; got %d", c.Expected, d.GetTimeDuration())
+			}
+		})
+	}
+}
+
+func TestParseDuration_Zeroes(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected string
+	}{
+		{Duration: "P000Y", Expected: "PT0S"},
+		{Duration: "P000Y000M000W000D", Expected: "PT0S"},
+		{Duration: "PT000H000M000S", Expected: "PT0S"},
+		{Duration: "PT00.000S", Expected: "PT0S"},
+		{Duration: "P000YT5S", Expected: "PT5S"},
+		{Duration: "P0001Y00M", Expected: "P1Y"},
+		{Duration: "P000Y0M0W00DT00H00M05S", Expected: "PT5S"},
+		{Duration: "-P000DT001H", Expected: "-PT1H"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Duration, func(t *testing.T) {
+			d, err := ParseDuration(c.Duration)
+			if err != nil {
+				t.Fatalf("expected to parse duration; got %v", err)
+			}
+
+			if got := d.String(); got != c.Expected {
+				t.Fatalf("expected duration %s; got %s", c.Expected, got)
+			}
+		})
+	}
+}
+
+func TestParseDuration_InvalidAllocations(t *testing.T) {
+	for _, c := range []string{"P6", "PT1S12H", "P+2Y", "P3Y6M6M", "PT1H 30M"} {
+		allocs := testing.AllocsPerRun(100, func() {
+			ParseDuration(c)
+		})
+
+		// The only allocation is the returned *ParseError, the static errors it wraps aren't allocated.
+		// Before ParseError the error path didn't allocate at all, the position is worth the one allocation.
+		if allocs > 1 {
+			t.Fatalf("expected at most one allocation parsing %s; got %v", c, allocs)
+		}
+	}
+}
+
+func TestParseDuration_ParseError(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Pos      int
+		Kind     error
+		Message  string
+	}{
+		{Duration: "P6", Pos: 2, Kind: ErrInvalidFormat, Message: "invalid format: missing designator"},
+		{Duration: "PT1S12H", Pos: 6, Kind: ErrInvalidFormat, Message: "invalid format: unexpected hour designator"},
+		{Duration: "  PX", Pos: 3, Kind: ErrInvalidFormat, Message: "invalid format: unexpected value or designator"},
+		{Duration: "P300Y", Pos: 4, Kind: ErrOverflow, Message: "year overflow"},
+		{Duration: "PT", Pos: 2, Kind: ErrInvalidFormat, Message: "invalid format: empty time section"},
+	}
+
+	for _, c := range cases {
+		_, err := ParseDuration(c.Duration)
+
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Fatalf("expected *ParseError for %q; got %T", c.Duration, err)
+		}
+
+		if pe.Input != c.Duration || pe.Pos != c.Pos || pe.Kind != c.Kind {
+			t.Fatalf("expected input %q at %d of kind %v; got %q at %d of kind %v", c.Duration, c.Pos, c.Kind, pe.Input, pe.Pos, pe.Kind)
+		}
+
+		if !errors.Is(err, c.Kind) || err.Error() != c.Message {
+			t.Fatalf("expected error %q matching %v; got %q", c.Message, c.Kind, err)
+		}
+	}
+}
+
+func TestParseError_OriginalInput(t *testing.T) {
+	cases := []struct {
+		Input string
+		Parse func(string) error
+		Pos   int
+	}{
+		{Input: " @P1X", Parse: func(s string) error { _, err := ParseDurationRelaxed(s); return err }, Pos: 4},
+		{Input: " PT-1Hx", Parse: func(s string) error { _, err := ParseDurationSigned(s); return err }, Pos: 6},
+		{Input: "P-1DT-2H-3Mx", Parse: func(s string) error { _, err := ParseDurationSigned(s); return err }, Pos: 11},
+		{Input: " R5/P1X", Parse: func(s string) error { _, _, _, err := ParseRecurring(s); return err }, Pos: 6},
+	}
+
+	for _, c := range cases {
+		var pe *ParseError
+		if err := c.Parse(c.Input); !errors.As(err, &pe) {
+			t.Fatalf("expected *ParseError for %q; got %T", c.Input, err)
+		}
+
+		if pe.Input != c.Input || pe.Pos != c.Pos {
+			t.Fatalf("expected input %q at %d; got %q at %d", c.Input, c.Pos, pe.Input, pe.Pos)
+		}
+	}
+}
+
+func TestParseDuration_Overflow(t *testing.T) {
+	cases := []string{
+		"P300Y",
+		"P292Y5M2W5DT21H47M17S",
+		"P292Y5M2W5DT21H47M16.854775808S",
+		"PT9223372037S",
+		"PT9223372036.854775808S",
+		"PT9223372036.999999999S",
+		"PT99999999999999999999S",
+		"P99999999999999999999D",
+		"PT2562048H",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			_, err := ParseDuration(c)
+			if !errors.Is(err, ErrOverflow) {
+				t.Fatalf("expected overflow error; got %v", err)
+			}
+		})
+	}
+}
+
+func TestDuration_PrecisionSafe(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected bool
+	}{
+		{Duration: "PT0S", Expected: true},
+		{Duration: "PT1.5S", Expected: true},
+		{Duration: "P1DT2H3M4.123456789S", Expected: true},
+		{Duration: "PT0.000000001S", Expected: true},
+		{Duration: "PT9999999.999999999S", Expected: false},
+		{Duration: "PT999999999.123456789S", Expected: false},
+		{Duration: "-PT99999999.123456S", Expected: true},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.PrecisionSafe(); got != c.Expected {
+			t.Fatalf("expected %s precision safe to be %t; got %t", c.Duration, c.Expected, got)
+		}
+	}
+}
+
+func TestDuration_WholeSecondsNanoseconds(t *testing.T) {
+	cases := []struct {
+		Duration     string
+		WholeSeconds int
+		Nanoseconds  int
+	}{
+		{Duration: "PT0S", WholeSeconds: 0, Nanoseconds: 0},
+		{Duration: "PT1.5S", WholeSeconds: 1, Nanoseconds: 500000000},
+		{Duration: "-PT1H2.000000001S", WholeSeconds: 2, Nanoseconds: 1},
+		{Duration: "P1DT59.999999999S", WholeSeconds: 59, Nanoseconds: 999999999},
+		{Duration: "PT999999999.123456789S", WholeSeconds: 999999999, Nanoseconds: 123456789},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if d.WholeSeconds() != c.WholeSeconds || d.Nanoseconds() != c.Nanoseconds {
+			t.Fatalf("expected %s to split into %d and %d; got %d and %d", c.Duration, c.WholeSeconds, c.Nanoseconds, d.WholeSeconds(), d.Nanoseconds())
+		}
+	}
+}
+
+func TestMaxDuration(t *testing.T) {
+	m := MaxDuration()
+
+	if m.GetTimeDuration() != math.MaxInt64 {
+		t.Fatalf("expected duration %d; got %d", int64(math.MaxInt64), m.GetTimeDuration())
+	}
+
+	if m.String() != "P292Y5M2W5DT21H47M16.854775807S" {
+		t.Fatalf("expected duration P292Y5M2W5DT21H47M16.854775807S; got %s", m)
+	}
+
+	d, err := ParseDuration(m.String())
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	if d.GetTimeDuration() != math.MaxInt64 {
+		t.Fatalf("expected duration %d; got %d", int64(math.MaxInt64), d.GetTimeDuration())
+	}
+
+	d, err = ParseDuration("-" + m.String())
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	if d.GetTimeDuration() != -math.MaxInt64 {
+		t.Fatalf("expected duration %d; got %d", -int64(math.MaxInt64), d.GetTimeDuration())
+	}
+}
+
+func TestDuration_Overflows(t *testing.T) {
+	cases := []struct {
+		Duration *Duration
+		Expected bool
+	}{
+		{Duration: &Duration{}, Expected: false},
+		{Duration: MaxDuration(), Expected: false},
+		{Duration: &Duration{years: 292, months: 6}, Expected: true},
+		{Duration: &Duration{years: 300}, Expected: true},
+		{Duration: &Duration{hours: 2562048}, Expected: true},
+		{Duration: &Duration{seconds: 1e10}, Expected: true},
+	}
+
+	for _, c := range cases {
+		if got := c.Duration.Overflows(); got != c.Expected {
+			t.Fatalf("expected %s overflows to be %t; got %t", c.Duration, c.Expected, got)
+		}
+	}
+}
+
+func TestDuration_FitsTimeDuration(t *testing.T) {
+	cases := []struct {
+		Components Components
+		Expected   bool
+	}{
+		{Components: Components{}, Expected: true},
+		{Components: Components{Years: 292, Months: 5, Weeks: 2, Days: 5, Hours: 21}, Expected: true},
+		{Components: Components{Years: 292, Months: 6}, Expected: false},
+		{Components: Components{Years: 1 << 40}, Expected: false},
+		{Components: Components{Hours: 2562048}, Expected: false},
+		{Components: Components{Seconds: 1e10}, Expected: false},
+		{Components: Components{Years: 200, Months: -120}, Expected: true},
+		{Components: Components{Years: -300}, Expected: false},
+		{Components: Components{Minutes: math.MaxInt}, Expected: false},
+		{Components: Components{Hours: 2562047, Seconds: -1e9}, Expected: true},
+	}
+
+	for _, c := range cases {
+		d := FromComponents(c.Components)
+		if got := d.FitsTimeDuration(); got != c.Expected {
+			t.Fatalf("expected %+v fits time duration to be %t; got %t", c.Components, c.Expected, got)
+		}
+	}
+
+	if !MaxDuration().FitsTimeDuration() {
+		t.Fatalf("expected max duration to fit time duration")
+	}
+}
+
+func TestParseDurationOr(t *testing.T) {
+	def, _ := ParseDuration("PT30S")
+
+	cases := []struct {
+		Input    string
+		Default  *Duration
+		Expected string
+	}{
+		{Input: "", Default: def, Expected: "PT30S"},
+		{Input: " \t", Default: def, Expected: "PT30S"},
+		{Input: "PT1M", Default: def, Expected: "PT1M"},
+		{Input: "", Default: nil, Expected: "PT0S"},
+	}
+
+	for _, c := range cases {
+		got, err := ParseDurationOr(c.Input, c.Default)
+		if err != nil || got.String() != c.Expected {
+			t.Fatalf("expected %q to give %s; got %v, %v", c.Input, c.Expected, got, err)
+		}
+
+		if got == c.Default {
+			t.Fatalf("expected a copy of the default for %q", c.Input)
+		}
+	}
+
+	if _, err := ParseDurationOr("P1X", def); !errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("expected ErrInvalidFormat for invalid input; got %v", err)
+	}
+}
+
+func TestParseDuration_Concurrent(t *testing.T) {
+	inputs := []string{"P1Y2M3W4DT5H6M7.5S", "-PT1H", "PT0S", "P1X", "  P2D  ", "PT1S12H", "P300Y", "PT0.000000001S"}
+
+	type result struct {
+		value string
+		err   string
+	}
+
+	expected := make([]result, len(inputs))
+	for i, input := range inputs {
+		d, err := ParseDuration(input)
+		if err != nil {
+			expected[i] = result{err: err.Error()}
+			continue
+		}
+
+		expected[i] = result{value: d.String()}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 64)
+
+	for g := range 32 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for n := range 200 {
+				i := (g + n) % len(inputs)
+
+				d, err := ParseDuration(inputs[i])
+				got := result{}
+				if err != nil {
+					got.err = err.Error()
+				} else {
+					got.value = d.String()
+				}
+
+				if got != expected[i] {
+					select {
+					case errs <- fmt.Sprintf("expected %q to give %+v; got %+v", inputs[i], expected[i], got):
+					default:
+					}
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestParseDurationEnv(t *testing.T) {
+	t.Setenv("DURAGO_PRIMARY_TIMEOUT", "")
+	t.Setenv("DURAGO_TIMEOUT", "PT30S")
+	t.Setenv("DURAGO_INVALID", "P1X")
+
+	d, err := ParseDurationEnv("DURAGO_UNSET", "DURAGO_PRIMARY_TIMEOUT", "DURAGO_TIMEOUT", "DURAGO_INVALID")
+	if err != nil || d.String() != "PT30S" {
+		t.Fatalf("expected duration PT30S; got %v, %v", d, err)
+	}
+
+	d, err = ParseDurationEnv("DURAGO_UNSET", "DURAGO_PRIMARY_TIMEOUT")
+	if d != nil || err != nil {
+		t.Fatalf("expected nil, nil for unset variables; got %v, %v", d, err)
+	}
+
+	_, err = ParseDurationEnv("DURAGO_INVALID", "DURAGO_TIMEOUT")
+	if !errors.Is(err, ErrInvalidFormat) || !strings.HasPrefix(err.Error(), "DURAGO_INVALID: ") {
+		t.Fatalf("expected invalid format error mentioning DURAGO_INVALID; got %v", err)
+	}
+}
+
+func TestParseDurationsAll(t *testing.T) {
+	durations, errs := ParseDurationsAll([]string{"PT1H", "P1X", "-P2D", "PT"})
+
+	if len(durations) != 4 || len(errs) != 4 {
+		t.Fatalf("expected 4 results; got %d durations and %d errors", len(durations), len(errs))
+	}
+
+	if durations[0].String() != "PT1H" || errs[0] != nil {
+		t.Fatalf("expected PT1H; got %v, %v", durations[0], errs[0])
+	}
+
+	if durations[1] != nil || !errors.Is(errs[1], ErrInvalidFormat) || errs[1].Error() != `"P1X": invalid format: malformed number` {
+		t.Fatalf("expected error for P1X; got %v, %v", durations[1], errs[1])
+	}
+
+	if durations[2].String() != "-P2D" || errs[2] != nil {
+		t.Fatalf("expected -P2D; got %v, %v", durations[2], errs[2])
+	}
+
+	if durations[3] != nil || !errors.Is(errs[3], ErrInvalidFormat) {
+		t.Fatalf("expected error for PT; got %v, %v", durations[3], errs[3])
+	}
+
+	durations, errs = ParseDurationsAll(nil)
+	if len(durations) != 0 || len(errs) != 0 {
+		t.Fatalf("expected no results; got %v, %v", durations, errs)
+	}
+}
+
+func TestParseDurationExtended(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Duration       string
+		Expected       time.Duration
+		ExpectedString string
+		ExpectedErr    string
+	}{
+		{
+			Name:     "fractional seconds",
+			Duration: "PT0.5S",
+			Expected: time.Millisecond * 500,
+		},
+		{
+			Name:     "milliseconds",
+			Duration: "PT500MS",
+			Expected: time.Millisecond * 500,
+		},
+		{
+			Name:     "seconds and milliseconds",
+			Duration: "PT5S500MS",
+			Expected: time.Second*5 + time.Millisecond*500,
+		},
+		{
+			Name:     "all sub-second units",
+			Duration: "PT1M1S2MS3US4NS",
+			Expected: time.Minute + time.Second + time.Millisecond*2 + time.Microsecond*3 + time.Nanosecond*4,
+		},
+		{
+			Name:           "fractional seconds and milliseconds",
+			Duration:       "PT0.1S200MS",
+			Expected:       time.Millisecond * 300,
+			ExpectedString: "PT0.3S",
+		},
+		{
+			Name:     "minutes still parse",
+			Duration: "PT5M",
+			Expected: time.Minute * 5,
+		},
+		{
+			Name:        "seconds after milliseconds",
+			Duration:    "PT5MS1S",
+			ExpectedErr: "invalid format: unexpected second designator",
+		},
+		{
+			Name:        "out of order",
+			Duration:    "PT5US1MS",
+			ExpectedErr: "invalid format: unexpected sub-second designator",
+		},
+		{
+			Name:        "fractional milliseconds",
+			Duration:    "PT1.5MS",
+			ExpectedErr: `sub-second parse failed: strconv.ParseInt: parsing "1.5": invalid syntax`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			d, err := ParseDurationExtended(c.Duration)
+			if err != nil || c.ExpectedErr != "" {
+				if err == nil || err.Error() != c.ExpectedErr {
+					t.Fatalf("expecting error '%s'; got '%v'", c.ExpectedErr, err)
+				}
+				return
+			}
+
+			if c.Expected != d.GetTimeDuration() {
+				t.Fatalf("expected duration %d; got %d", c.Expected, d.GetTimeDuration())
+			}
+
+			if c.ExpectedString != "" && c.ExpectedString != d.String() {
+				t.Fatalf("expected duration %s; got %s", c.ExpectedString, d.String())
+			}
+		})
+	}
+
+	if _, err := ParseDuration("PT500MS"); err == nil {
+		t.Fatalf("expected ParseDuration to reject extended designators")
+	}
+}
+
+func TestDuration_AsInt64Nanos_AsFloat64Seconds(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Nanos    int64
+		Seconds  float64
+	}{
+		{Duration: "PT0S"},
+		{Duration: "PT1.5S", Nanos: 1500000000, Seconds: 1.5},
+		{Duration: "-PT1M", Nanos: -60000000000, Seconds: -60},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.AsInt64Nanos(); got != c.Nanos {
+			t.Fatalf("expected %s to be %d nanoseconds; got %d", c.Duration, c.Nanos, got)
+		}
+
+		if got := d.AsFloat64Seconds(); got != c.Seconds {
+			t.Fatalf("expected %s to be %v seconds; got %v", c.Duration, c.Seconds, got)
+		}
+	}
+}
+
+func TestDuration_ZeroValue(t *testing.T) {
+	var d Duration
+
+	if got := d.String(); got != "PT0S" {
+		t.Fatalf("expected duration PT0S; got %s", got)
+	}
+
+	if !d.IsZero() {
+		t.Fatalf("expected zero value to be zero")
+	}
+
+	if got := d.GetTimeDuration(); got != 0 {
+		t.Fatalf("expected duration 0; got %d", got)
+	}
+
+	jsoned, err := json.Marshal(struct {
+		Duration Duration `json:"duration"`
+	}{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if string(jsoned) != `{"duration":"PT0S"}` {
+		t.Fatalf("expected duration %s; got %s", `{"duration":"PT0S"}`, string(jsoned))
+	}
+
+	parsed, err := ParseDuration("PT0S")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	if !reflect.DeepEqual(*parsed, d) {
+		t.Fatalf("expected parsed PT0S to equal the zero value; got %#v", parsed)
+	}
+}
+
+func TestDuration_IsZero(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected bool
+	}{
+		{Duration: "PT0S", Expected: true},
+		{Duration: "-P0D", Expected: true},
+		{Duration: "PT0.000000001S", Expected: false},
+		{Duration: "P1Y", Expected: false},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.IsZero(); got != c.Expected {
+			t.Fatalf("expected %s is zero to be %t; got %t", c.Duration, c.Expected, got)
+		}
+	}
+}
+
+func TestParseDurationLenient(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Duration    string
+		Expected    time.Duration
+		ExpectedErr string
+	}{
+		{
+			Name:     "hours and minutes without time designator",
+			Duration: "P1H30M",
+			Expected: time.Hour + time.Minute*30,
+		},
+		{
+			Name:     "days and seconds without time designator",
+			Duration: "P1D5S",
+			Expected: timeDay + time.Second*5,
+		},
+		{
+			Name:     "months stay months",
+			Duration: "P30M",
+			Expected: timeMonth * 30,
+		},
+		{
+			Name:     "months and hours",
+			Duration: "P2M3H4M",
+			Expected: timeMonth*2 + time.Hour*3 + time.Minute*4,
+		},
+		{
+			Name:     "strict input",
+			Duration: "P1DT1H30M",
+			Expected: timeDay + time.Hour + time.Minute*30,
+		},
+		{
+			Name:        "time designator after hours",
+			Duration:    "P1HT30M",
+			ExpectedErr: "invalid format: unexpected time designator",
+		},
+		{
+			Name:        "day after hours",
+			Duration:    "P1H1D",
+			ExpectedErr: "invalid format: unexpected day designator",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			d, err := ParseDurationLenient(c.Duration)
+			if err != nil || c.ExpectedErr != "" {
+				if err == nil || err.Error() != c.ExpectedErr {
+					t.Fatalf("expecting error '%s'; got '%v'", c.ExpectedErr, err)
+				}
+				return
+			}
+
+			if c.Expected != d.GetTimeDuration() {
+				t.Fatalf("expected duration %d; got %d", c.Expected, d.GetTimeDuration())
+			}
+		})
+	}
+
+	if _, err := ParseDuration("P1H30M"); err == nil {
+		t.Fatalf("expected ParseDuration to require the time designator")
+	}
+}
+
+func TestParseDurationReadable(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Duration    string
+		Expected    time.Duration
+		ExpectedErr string
+	}{
+		{
+			Name:     "thousands separator",
+			Duration: "P1_000D",
+			Expected: timeDay * 1000,
+		},
+		{
+			Name:     "separators in fraction",
+			Duration: "PT1_0.000_5S",
+			Expected: time.Second*10 + time.Microsecond*500,
+		},
+		{
+			Name:     "no separators",
+			Duration: "P1DT2H",
+			Expected: timeDay + time.Hour*2,
+		},
+		{
+			Name:        "leading separator",
+			Duration:    "P_1D",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "doubled separator",
+			Duration:    "P1__0D",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "trailing separator",
+			Duration:    "P1_D",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "separator before decimal point",
+			Duration:    "PT1_.5S",
+			ExpectedErr: "invalid format: malformed number",
+		},
+		{
+			Name:        "separator after decimal point",
+			Duration:    "PT1._5S",
+			ExpectedErr: "invalid format: malformed number",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			d, err := ParseDurationReadable(c.Duration)
+			if err != nil || c.ExpectedErr != "" {
+				if err == nil || err.Error() != c.ExpectedErr {
+					t.Fatalf("expecting error '%s'; got '%v'", c.ExpectedErr, err)
+				}
+				return
+			}
+
+			if c.Expected != d.GetTimeDuration() {
+				t.Fatalf("expected duration %d; got %d", c.Expected, d.GetTimeDuration())
+			}
+		})
+	}
+
+	if _, err := ParseDuration("P1_000D"); err == nil {
+		t.Fatalf("expected ParseDuration to reject digit separators")
+	}
+}
+
+func TestParseDurationStrict(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Duration    string
+		Expected    time.Duration
+		ExpectedErr string
+	}{
+		{
+			Name:     "within range",
+			Duration: "PT1H59M59.999S",
+			Expected: time.Hour + time.Minute*59 + time.Millisecond*59999,
+		},
+		{
+			Name:     "unbounded hours",
+			Duration: "PT36H",
+			Expected: time.Hour * 36,
+		},
+		{
+			Name:        "60 seconds",
+			Duration:    "PT60S",
+			ExpectedErr: "invalid format: seconds out of range",
+		},
+		{
+			Name:        "leap second",
+			Duration:    "PT1M61S",
+			ExpectedErr: "invalid format: seconds out of range",
+		},
+		{
+			Name:        "90 minutes",
+			Duration:    "PT90M",
+			ExpectedErr: "invalid format: minutes out of range",
+		},
+		{
+			Name:     "18 digits",
+			Duration: "P000000000000000001D",
+			Expected: time.Hour * 24,
+		},
+		{
+			Name:     "18 digits with fraction",
+			Duration: "PT1.00000000000000000S",
+			Expected: time.Second,
+		},
+		{
+			Name:        "19 digits",
+			Duration:    "P0000000000000000001D",
+			ExpectedErr: "invalid format: numeric field too long",
+		},
+		{
+			Name:        "19 digits with fraction",
+			Duration:    "PT1.000000000000000000S",
+			ExpectedErr: "invalid format: numeric field too long",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			d, err := ParseDurationStrict(c.Duration)
+			if err != nil || c.ExpectedErr != "" {
+				if err == nil || err.Error() != c.ExpectedErr {
+					t.Fatalf("expecting error '%s'; got '%v'", c.ExpectedErr, err)
+				}
+				return
+			}
+
+			if c.Expected != d.GetTimeDuration() {
+				t.Fatalf("expected duration %d; got %d", c.Expected, d.GetTimeDuration())
+			}
+		})
+	}
+
+	for _, c := range []string{"PT60S", "PT90S", "PT90M"} {
+		if _, err := ParseDuration(c); err != nil {
+			t.Fatalf("expected ParseDuration to accept %s; got %v", c, err)
+		}
+	}
+}
+
+func TestParseDurationRelaxed(t *testing.T) {
+	cases := []struct {
+		Duration    string
+		Prefixes    []rune
+		Expected    time.Duration
+		ExpectedErr bool
+	}{
+		{Duration: "@P1D", Expected: timeDay},
+		{Duration: " @-PT1H ", Expected: -time.Hour},
+		{Duration: "PT1H", Expected: time.Hour},
+		{Duration: "#PT1H", Prefixes: []rune{'@', '#'}, Expected: time.Hour},
+		{Duration: "#PT1H", ExpectedErr: true},
+		{Duration: "@@P1D", ExpectedErr: true},
+		{Duration: "@P1D", Prefixes: []rune{'#'}, ExpectedErr: true},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDurationRelaxed(c.Duration, c.Prefixes...)
+		if c.ExpectedErr {
+			if err == nil {
+				t.Fatalf("expected error parsing %s with prefixes %q", c.Duration, c.Prefixes)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("expected to parse %s; got %v", c.Duration, err)
+		}
+
+		if d.GetTimeDuration() != c.Expected {
+			t.Fatalf("expected duration %d; got %d", c.Expected, d.GetTimeDuration())
+		}
+	}
+
+	if _, err := ParseDuration("@P1D"); err == nil {
+		t.Fatalf("expected ParseDuration to reject the @ prefix")
+	}
+}
+
+func TestParseDurationPrefix(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Input       string
+		Expected    string
+		Rest        string
+		ExpectedErr string
+	}{
+		{
+			Name:     "whole input",
+			Input:    "P1DT2H",
+			Expected: "P1DT2H",
+		},
+		{
+			Name:     "trailing words",
+			Input:    "PT1H30M and more",
+			Expected: "PT1H30M",
+			Rest:     " and more",
 		},
 		{
-			Name:        "missing designator after valid designator in time",
-			Duration:    "PT1S12",
-			ExpectedErr: "invalid format: missing designator",
+			Name:     "leading whitespace",
+			Input:    "  P1D,PT1H",
+			Expected: "P1D",
+			Rest:     ",PT1H",
 		},
 		{
-			Name:        "unexpected hour designator",
-			Duration:    "PT1S12H",
-			ExpectedErr: "invalid format: unexpected hour designator",
+			Name:     "dangling number",
+			Input:    "PT1H30",
+			Expected: "PT1H",
+			Rest:     "30",
 		},
 		{
-			Name:        "unexpected positive sign",
-			Duration:    "P+2Y",
-			ExpectedErr: "invalid format: unexpected positive sign",
+			Name:     "dangling number before tail",
+			Input:    "P1D12x",
+			Expected: "P1D",
+			Rest:     "12x",
 		},
 		{
-			Name:        "duplicate designator",
-			Duration:    "P3Y6M6M2W4DT12H30M5S",
-			ExpectedErr: "invalid format: unexpected month designator",
+			Name:     "dangling decimal point",
+			Input:    "PT1H5.x",
+			Expected: "PT1H",
+			Rest:     "5.x",
+		},
+		{
+			Name:     "immediate tail",
+			Input:    "P2W;",
+			Expected: "P2W",
+			Rest:     ";",
+		},
+		{
+			Name:        "invalid order",
+			Input:       "P1D1Y rest",
+			ExpectedErr: "invalid format: unexpected year designator",
+		},
+		{
+			Name:        "empty time section",
+			Input:       "PT rest",
+			ExpectedErr: "invalid format: no duration components",
+		},
+		{
+			Name:     "sign after the start",
+			Input:    "P1D-x",
+			Expected: "P1D",
+			Rest:     "-x",
+		},
+		{
+			Name:     "second period designator",
+			Input:    "P1DP2D",
+			Expected: "P1D",
+			Rest:     "P2D",
+		},
+		{
+			Name:     "decimal point without digits",
+			Input:    "P1D.",
+			Expected: "P1D",
+			Rest:     ".",
+		},
+		{
+			Name:     "second decimal point",
+			Input:    "PT1H2.5.1S",
+			Expected: "PT1H",
+			Rest:     "2.5.1S",
+		},
+		{
+			Name:     "time designator without components",
+			Input:    "P1DTfoo",
+			Expected: "P1D",
+			Rest:     "Tfoo",
+		},
+		{
+			Name:     "time designator with dangling number",
+			Input:    "P1DT5",
+			Expected: "P1D",
+			Rest:     "T5",
+		},
+		{
+			Name:     "time designator at the end",
+			Input:    "P1DT",
+			Expected: "P1D",
+			Rest:     "T",
+		},
+		{
+			Name:     "designator without value",
+			Input:    "PT1HMORE",
+			Expected: "PT1H",
+			Rest:     "MORE",
+		},
+		{
+			Name:        "no duration",
+			Input:       "foo bar",
+			ExpectedErr: "invalid format: no duration components",
+		},
+		{
+			Name:        "number without duration",
+			Input:       "5 apples",
+			ExpectedErr: "invalid format: no duration components",
 		},
 	}
 
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
-			d, err := ParseDuration(c.Duration)
+			d, rest, err := ParseDurationPrefix(c.Input)
 			if err != nil || c.ExpectedErr != "" {
 				if err == nil || err.Error() != c.ExpectedErr {
 					t.Fatalf("expecting error '%s'; got '%v'", c.ExpectedErr, err)
@@ -98,11 +1213,19 @@ func TestParseDuration(t *testing.T) {
 				return
 			}
 
-			if c.Expected != d.GetTimeDuration() {
-				t.Fatalf("expected duration %d; got %d", c.Expected, d.GetTimeDuration())
+			if d.String() != c.Expected {
+				t.Fatalf("expected duration %s; got %s", c.Expected, d)
+			}
+
+			if rest != c.Rest {
+				t.Fatalf("expected rest %q; got %q", c.Rest, rest)
 			}
 		})
 	}
+
+	if _, err := ParseDuration("PT1H30M and more"); err == nil {
+		t.Fatalf("expected ParseDuration to reject trailing content")
+	}
 }
 
 func TestFromTimeDuration(t *testing.T) {
@@ -153,6 +1276,37 @@ func TestFromTimeDuration(t *testing.T) {
 	}
 }
 
+func TestFromTimeDurationClock(t *testing.T) {
+	cases := []struct {
+		Duration time.Duration
+		Expected string
+	}{
+		{Duration: 0, Expected: "PT0S"},
+		{Duration: 90 * time.Minute, Expected: "PT1H30M"},
+		{Duration: 50 * time.Hour, Expected: "PT50H"},
+		{Duration: -(timeYear + time.Second), Expected: "-PT8760H1S"},
+		{Duration: 59*time.Second + 999999999, Expected: "PT59.999999999S"},
+		{Duration: time.Hour + time.Nanosecond, Expected: "PT1H0.000000001S"},
+		{Duration: maxDuration, Expected: "PT2562047H47M16.854775807S"},
+	}
+
+	for _, c := range cases {
+		got := FromTimeDurationClock(c.Duration)
+		if got.String() != c.Expected {
+			t.Fatalf("expected %s; got %s", c.Expected, got)
+		}
+
+		parsed, err := ParseDuration(got.String())
+		if err != nil {
+			t.Fatalf("expected to parse %s; got %v", got, err)
+		}
+
+		if parsed.GetTimeDuration() != c.Duration {
+			t.Fatalf("expected %s to round-trip to %d; got %d", got, c.Duration, parsed.GetTimeDuration())
+		}
+	}
+}
+
 func TestDuration_GetTimeDuration(t *testing.T) {
 	cases := []struct {
 		Duration *Duration
@@ -209,6 +1363,202 @@ func TestDuration_String(t *testing.T) {
 	}
 }
 
+func TestDuration_String_SignedRoundTrip(t *testing.T) {
+	components := []struct {
+		Value string
+		Time  bool
+	}{
+		{Value: "1Y"},
+		{Value: "2M"},
+		{Value: "3W"},
+		{Value: "4D"},
+		{Value: "5H", Time: true},
+		{Value: "6M", Time: true},
+		{Value: "7.5S", Time: true},
+	}
+
+	for mask := 1; mask < 1<<len(components); mask++ {
+		var period, clock string
+		for i, c := range components {
+			if mask&(1<<i) == 0 {
+				continue
+			}
+
+			if c.Time {
+				clock += c.Value
+			} else {
+				period += c.Value
+			}
+		}
+
+		canonical := "P" + period
+		if clock != "" {
+			canonical += "T" + clock
+		}
+
+		for _, sign := range []string{"-", "+", ""} {
+			expected := canonical
+			if sign == "-" {
+				expected = "-" + canonical
+			}
+
+			d, err := ParseDuration(sign + canonical)
+			if err != nil {
+				t.Fatalf("expected to parse %s; got %v", sign+canonical, err)
+			}
+
+			if got := d.String(); got != expected {
+				t.Fatalf("expected %s to stringify as %s; got %s", sign+canonical, expected, got)
+			}
+
+			again, err := ParseDuration(d.String())
+			if err != nil {
+				t.Fatalf("expected to parse %s; got %v", d, err)
+			}
+
+			if !reflect.DeepEqual(again, d) {
+				t.Fatalf("expected %s to round trip; got %#v", d, *again)
+			}
+		}
+	}
+}
+
+func TestDuration_StringPrec(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Digits   int
+		Expected string
+	}{
+		{Duration: "PT1.5S", Digits: 3, Expected: "PT1.500S"},
+		{Duration: "PT1.5S", Digits: 1, Expected: "PT1.5S"},
+		{Duration: "PT1.25S", Digits: 1, Expected: "PT1.2S"},
+		{Duration: "PT1.123456S", Digits: 3, Expected: "PT1.123S"},
+		{Duration: "-P1DT2S", Digits: 2, Expected: "-P1DT2.00S"},
+		{Duration: "PT1.5S", Digits: 0, Expected: "PT2S"},
+		{Duration: "PT1.4S", Digits: -1, Expected: "PT1S"},
+		{Duration: "PT1H", Digits: 3, Expected: "PT1H"},
+		{Duration: "PT0S", Digits: 3, Expected: "PT0S"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.StringPrec(c.Digits); got != c.Expected {
+			t.Fatalf("expected %s with %d digits to be %s; got %s", c.Duration, c.Digits, c.Expected, got)
+		}
+	}
+}
+
+func TestDuration_StringLower(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected string
+	}{
+		{Duration: "P1Y2M3DT4H", Expected: "p1y2m3dt4h"},
+		{Duration: "-P1WT1.5S", Expected: "-p1wt1.5s"},
+		{Duration: "PT0S", Expected: "pt0s"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.StringLower(); got != c.Expected {
+			t.Fatalf("expected duration %s; got %s", c.Expected, got)
+		}
+
+		if got := d.String(); got != c.Duration {
+			t.Fatalf("expected duration %s; got %s", c.Duration, got)
+		}
+	}
+}
+
+func TestDuration_GoString(t *testing.T) {
+	d, err := ParseDuration("-PT1H30M")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	if got := d.GoString(); got != "-1h30m0s" {
+		t.Fatalf("expected duration -1h30m0s; got %s", got)
+	}
+
+	if got := fmt.Sprintf("%#v", d); got != "-1h30m0s" {
+		t.Fatalf("expected duration -1h30m0s; got %s", got)
+	}
+
+	if got := d.String(); got != "-PT1H30M" {
+		t.Fatalf("expected duration -PT1H30M; got %s", got)
+	}
+}
+
+func TestDuration_Append(t *testing.T) {
+	d, err := ParseDuration("-P1Y2M3W4DT5H6M7.5S")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	got := d.Append([]byte("duration="))
+	if string(got) != "duration=-P1Y2M3W4DT5H6M7.5S" {
+		t.Fatalf("expected duration=-P1Y2M3W4DT5H6M7.5S; got %s", got)
+	}
+
+	got = (&Duration{}).Append(nil)
+	if string(got) != zeroDuration {
+		t.Fatalf("expected %s; got %s", zeroDuration, got)
+	}
+}
+
+func TestDuration_WriteTo(t *testing.T) {
+	d, err := ParseDuration("P3Y6M4DT12H30M5.5S")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	var b bytes.Buffer
+
+	n, err := d.WriteTo(&b)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if b.String() != "P3Y6M4DT12H30M5.5S" {
+		t.Fatalf("expected duration P3Y6M4DT12H30M5.5S; got %s", b.String())
+	}
+
+	if n != int64(b.Len()) {
+		t.Fatalf("expected %d bytes written; got %d", b.Len(), n)
+	}
+}
+
+func TestDuration_LogValue(t *testing.T) {
+	d, err := ParseDuration("PT30S")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	var b bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	logger.Info("timeout", "dur", d, "plain", *d)
+
+	if expected := "level=INFO msg=timeout dur=PT30S plain=PT30S\n"; b.String() != expected {
+		t.Fatalf("expected log %q; got %q", expected, b.String())
+	}
+}
+
 func TestDuration_MarshalJSON(t *testing.T) {
 	d, err := ParseDuration("P3Y6M4DT12H30M5.5S")
 	if err != nil {
@@ -238,6 +1588,27 @@ func TestDuration_MarshalJSON(t *testing.T) {
 	}
 }
 
+func TestDuration_MarshalJSONNanos(t *testing.T) {
+	d, err := ParseDuration("-PT1M0.5S")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	got, err := d.MarshalJSONNanos()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if string(got) != "-60500000000" {
+		t.Fatalf("expected -60500000000; got %s", got)
+	}
+
+	var nanos int64
+	if err := json.Unmarshal(got, &nanos); err != nil || nanos != d.AsInt64Nanos() {
+		t.Fatalf("expected valid JSON integer %d; got %d, %v", d.AsInt64Nanos(), nanos, err)
+	}
+}
+
 func TestDuration_UnmarshalJSON(t *testing.T) {
 	jsoned := `{"duration":"P3Y6M4DT12H30M5.5S"}`
 
@@ -271,6 +1642,60 @@ func TestDuration_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestDuration_UnmarshalJSON_Hybrid(t *testing.T) {
+	cases := []struct {
+		JSON        string
+		Expected    time.Duration
+		ExpectedErr bool
+	}{
+		{JSON: `{"duration":"PT1M30S"}`, Expected: time.Second * 90},
+		{JSON: `{"duration":90}`, Expected: time.Second * 90},
+		{JSON: `{"duration":1.5}`, Expected: time.Millisecond * 1500},
+		{JSON: `{"duration":-3600}`, Expected: -time.Hour},
+		{JSON: `{"duration":0}`, Expected: 0},
+		{JSON: `{"duration":null}`, Expected: 0},
+		{JSON: `{}`, Expected: 0},
+		{JSON: `{"duration":1e300}`, ExpectedErr: true},
+		{JSON: `{"duration":true}`, ExpectedErr: true},
+		{JSON: `{"duration":"90"}`, ExpectedErr: true},
+	}
+
+	for _, c := range cases {
+		var v struct {
+			Duration Duration `json:"duration"`
+		}
+
+		err := json.Unmarshal([]byte(c.JSON), &v)
+		if c.ExpectedErr {
+			if err == nil {
+				t.Fatalf("expected error unmarshaling %s", c.JSON)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected err unmarshaling %s: %v", c.JSON, err)
+		}
+
+		if got := v.Duration.GetTimeDuration(); got != c.Expected {
+			t.Fatalf("expected %s to be %d; got %d", c.JSON, c.Expected, got)
+		}
+	}
+
+	var ptr struct {
+		Duration *Duration `json:"duration"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"duration":60}`), &ptr); err != nil || ptr.Duration.String() != "PT1M" {
+		t.Fatalf("expected duration PT1M; got %v, %v", ptr.Duration, err)
+	}
+
+	d, _ := ParseDuration("PT1H")
+	if err := d.UnmarshalJSON([]byte("null")); err != nil || *d != (Duration{}) {
+		t.Fatalf("expected null to reset the duration; got %s, %v", d, err)
+	}
+}
+
 func BenchmarkParseDuration(b *testing.B) {
 	duration := "+P3Y6M1W4DT12H30M5S"
 
@@ -279,6 +1704,30 @@ func BenchmarkParseDuration(b *testing.B) {
 	}
 }
 
+func BenchmarkParseDuration_Parallel(b *testing.B) {
+	durations := []string{"+P3Y6M1W4DT12H30M5S", "PT1H", "-P1DT0.5S", "P1X"}
+
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			ParseDuration(durations[i%len(durations)])
+		}
+	})
+}
+
+func BenchmarkParseDuration_Invalid(b *testing.B) {
+	durations := []string{"P6", "PT1S12H", "P+2Y", "P3Y6M6M2W4DT12H30M5S"}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		for _, d := range durations {
+			ParseDuration(d)
+		}
+	}
+}
+
 func BenchmarkDuration_String(b *testing.B) {
 	duration := "+P99Y11M4W30DT23H59M59S"
 	d, _ := ParseDuration(duration)
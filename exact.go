@@ -0,0 +1,59 @@
+package durago
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrMismatch = errors.New("iso and nanoseconds mismatch")
+
+// ExactDuration wraps a Duration to marshal both its ISO8601 string and its exact nanoseconds,
+// e.g. {"iso":"P1M","ns":2628000000000000}. Storing both allows to audit whether a stored calendar duration
+// is later interpreted with a different year or month length.
+type ExactDuration struct {
+	Duration
+}
+
+type exactDurationJSON struct {
+	ISO *string `json:"iso,omitempty"`
+	NS  *int64  `json:"ns,omitempty"`
+}
+
+// MarshalJSON satisfies the Marshaler interface by returning an object with the ISO8601 string and the nanoseconds
+func (e ExactDuration) MarshalJSON() ([]byte, error) {
+	iso := e.String()
+	ns := e.AsInt64Nanos()
+
+	return json.Marshal(exactDurationJSON{ISO: &iso, NS: &ns})
+}
+
+// UnmarshalJSON satisfies the Unmarshaler interface by parsing an object with the ISO8601 string, the nanoseconds or both.
+// If both are present the ISO8601 string wins, but it must resolve to the same nanoseconds or ErrMismatch is returned.
+func (e *ExactDuration) UnmarshalJSON(source []byte) error {
+	var v exactDurationJSON
+	if err := json.Unmarshal(source, &v); err != nil {
+		return err
+	}
+
+	switch {
+	case v.ISO != nil:
+		parsed, err := ParseDuration(*v.ISO)
+		if err != nil {
+			return err
+		}
+
+		if v.NS != nil && parsed.AsInt64Nanos() != *v.NS {
+			return fmt.Errorf("%w: %s is %d nanoseconds, not %d", ErrMismatch, *v.ISO, parsed.AsInt64Nanos(), *v.NS)
+		}
+
+		e.Duration = *parsed
+	case v.NS != nil:
+		e.Duration = *FromTimeDuration(time.Duration(*v.NS))
+	default:
+		e.Duration = Duration{}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package durago
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestExactDuration_MarshalJSON(t *testing.T) {
+	d, err := ParseDuration("P1M")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	jsoned, err := json.Marshal(struct {
+		Duration ExactDuration `json:"duration"`
+	}{Duration: ExactDuration{*d}})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if expected := `{"duration":{"iso":"P1M","ns":2628000000000000}}`; string(jsoned) != expected {
+		t.Fatalf("expected %s; got %s", expected, jsoned)
+	}
+
+	jsoned, err = json.Marshal(ExactDuration{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if expected := `{"iso":"PT0S","ns":0}`; string(jsoned) != expected {
+		t.Fatalf("expected %s; got %s", expected, jsoned)
+	}
+}
+
+func TestExactDuration_UnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		JSON        string
+		Expected    string
+		ExpectedErr error
+	}{
+		{JSON: `{"iso":"P1M","ns":2628000000000000}`, Expected: "P1M"},
+		{JSON: `{"iso":"PT90M"}`, Expected: "PT90M"},
+		{JSON: `{"ns":5400000000000}`, Expected: "PT1H30M"},
+		{JSON: `{}`, Expected: "PT0S"},
+		{JSON: `{"iso":"P1M","ns":2592000000000000}`, ExpectedErr: ErrMismatch},
+		{JSON: `{"iso":"P1X"}`, ExpectedErr: ErrInvalidFormat},
+	}
+
+	for _, c := range cases {
+		var e ExactDuration
+
+		err := json.Unmarshal([]byte(c.JSON), &e)
+		if c.ExpectedErr != nil {
+			if !errors.Is(err, c.ExpectedErr) {
+				t.Fatalf("expected error %v unmarshaling %s; got %v", c.ExpectedErr, c.JSON, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected err unmarshaling %s: %v", c.JSON, err)
+		}
+
+		if got := e.String(); got != c.Expected {
+			t.Fatalf("expected %s to be %s; got %s", c.JSON, c.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package durago
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// fixedFields lists the bit widths of the components packed into the second half of the fixed layout.
+var fixedFields = [...]uint{12, 10, 10, 12, 12, 6}
+
+const (
+	fixedNegative   = 1 << 0
+	fixedComponents = 1 << 1
+)
+
+// MarshalFixed encodes the duration into a fixed 16 byte layout suited for direct indexing, e.g. in mmap'd files.
+// Bytes 0-7 hold the signed nanoseconds as a little-endian int64, the wall-clock value always round-trips exactly.
+// Bytes 8-15 hold a little-endian uint64 with the sign in bit 0 and, if bit 1 is set, the components:
+// years in bits 2-13, months in bits 14-23, weeks in bits 24-33, days in bits 34-45, hours in bits 46-57
+// and minutes in bits 58-63, the seconds are the nanoseconds left over. Components too large for their bits
+// aren't stored, UnmarshalFixed then restores them the way FromTimeDuration does.
+func (d *Duration) MarshalFixed() [16]byte {
+	var b [16]byte
+	binary.LittleEndian.PutUint64(b[:8], uint64(d.GetTimeDuration()))
+
+	var packed uint64
+	if d.negative && d.d != 0 {
+		packed |= fixedNegative
+	}
+
+	values := [...]int{d.years, d.months, d.weeks, d.days, d.hours, d.minutes}
+
+	fits := d.secondsDuration() >= 0
+	for i, v := range values {
+		fits = fits && v >= 0 && v < 1<<fixedFields[i]
+	}
+
+	if fits {
+		packed |= fixedComponents
+
+		shift := uint(2)
+		for i, v := range values {
+			packed |= uint64(v) << shift
+			shift += fixedFields[i]
+		}
+	}
+
+	binary.LittleEndian.PutUint64(b[8:], packed)
+
+	return b
+}
+
+// UnmarshalFixed decodes a duration encoded by MarshalFixed.
+func UnmarshalFixed(b [16]byte) *Duration {
+	ns := time.Duration(binary.LittleEndian.Uint64(b[:8]))
+	packed := binary.LittleEndian.Uint64(b[8:])
+
+	if packed&fixedComponents == 0 {
+		return FromTimeDuration(ns)
+	}
+
+	var values [len(fixedFields)]int
+
+	shift := uint(2)
+	for i, bits := range fixedFields {
+		values[i] = int(packed >> shift & (1<<bits - 1))
+		shift += bits
+	}
+
+	magnitude := ns
+	if magnitude < 0 {
+		magnitude = -magnitude
+	}
+
+	duration := &Duration{
+		d:        magnitude,
+		negative: packed&fixedNegative != 0,
+		years:    values[0],
+		months:   values[1],
+		weeks:    values[2],
+		days:     values[3],
+		hours:    values[4],
+		minutes:  values[5],
+	}
+
+	seconds := duration.secondsDuration()
+	if seconds < 0 || duration.negative != (ns < 0) {
+		return FromTimeDuration(ns)
+	}
+
+	duration.seconds = seconds.Seconds()
+
+	return duration
+}
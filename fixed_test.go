@@ -0,0 +1,43 @@
+package durago
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestDuration_MarshalFixed(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected string
+	}{
+		{Duration: "PT0S", Expected: "PT0S"},
+		{Duration: "P1Y2M3W4DT5H6M7.5S", Expected: "P1Y2M3W4DT5H6M7.5S"},
+		{Duration: "-P1DT0.000000001S", Expected: "-P1DT0.000000001S"},
+		{Duration: "PT90S", Expected: "PT90S"},
+		{Duration: "P40D", Expected: "P40D"},
+		{Duration: "PT5000H", Expected: "P6M3W4DT20H"},
+		{Duration: "PT64M", Expected: "PT1H4M"},
+		{Duration: "P292Y5M2W5DT21H47M16.854775807S", Expected: "P292Y5M2W5DT21H47M16.854775807S"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		b := d.MarshalFixed()
+		if ns := int64(binary.LittleEndian.Uint64(b[:8])); ns != d.AsInt64Nanos() {
+			t.Fatalf("expected %s to store %d nanoseconds; got %d", c.Duration, d.AsInt64Nanos(), ns)
+		}
+
+		got := UnmarshalFixed(b)
+		if got.String() != c.Expected {
+			t.Fatalf("expected %s to round-trip as %s; got %s", c.Duration, c.Expected, got)
+		}
+
+		if got.GetTimeDuration() != d.GetTimeDuration() {
+			t.Fatalf("expected %s to keep %d; got %d", c.Duration, d.GetTimeDuration(), got.GetTimeDuration())
+		}
+	}
+}
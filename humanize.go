@@ -0,0 +1,293 @@
+package durago
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Ago describes the duration relative to now using its most significant non-zero component,
+// e.g. "3 days ago" for P3DT4H or "in 2 hours" for -PT2H. Durations below a minute are "just now".
+// The components are normalized first, so PT90S is "1 minute ago"; weeks are only used if the duration has any.
+func (d *Duration) Ago() string {
+	n := d.NormalizeOpts(d.weeks != 0)
+
+	units := []struct {
+		value int
+		name  string
+	}{
+		{n.years, "year"},
+		{n.months, "month"},
+		{n.weeks, "week"},
+		{n.days, "day"},
+		{n.hours, "hour"},
+		{n.minutes, "minute"},
+	}
+
+	for _, u := range units {
+		if u.value == 0 {
+			continue
+		}
+
+		s := pluralize(u.value, u.name)
+		if d.negative {
+			return "in " + s
+		}
+
+		return s + " ago"
+	}
+
+	return "just now"
+}
+
+// pluralize returns the count followed by the unit name, pluralized when needed.
+func pluralize(n int, name string) string {
+	s := strconv.Itoa(n) + " " + name
+	if n != 1 {
+		s += "s"
+	}
+
+	return s
+}
+
+// Catalog provides the localized phrases used by HumanizeLang, so durations can be rendered in any language
+// without depending on an i18n library.
+type Catalog interface {
+	// Phrase returns the count together with the localized unit name, pluralized as the language requires,
+	// e.g. "2 heures" for UnitHour and 2.
+	Phrase(unit Unit, count int) string
+}
+
+// EnglishCatalog is the default Catalog, producing phrases such as "1 day" and "3 hours".
+type EnglishCatalog struct{}
+
+// Phrase satisfies the Catalog interface.
+func (EnglishCatalog) Phrase(unit Unit, count int) string {
+	return pluralize(count, unit.String())
+}
+
+// Humanize renders every non-zero component in English, e.g. "1 day 3 hours" for P1DT3H.
+// It's HumanizeLang with EnglishCatalog.
+func (d *Duration) Humanize() string {
+	return d.HumanizeLang(EnglishCatalog{})
+}
+
+// HumanizeLang renders every non-zero component with the phrases of cat, separated by spaces, e.g. "2 jours 3 heures"
+// with a French catalog. Fractional seconds are truncated, a zero duration is rendered as 0 seconds
+// and a negative one is prefixed with a minus sign.
+func (d *Duration) HumanizeLang(cat Catalog) string {
+	phrases := make([]string, 0, 7)
+	for unit, value := range d.Units() {
+		count := int(value)
+		if unit == UnitSecond {
+			count = d.WholeSeconds()
+		}
+
+		if count != 0 {
+			phrases = append(phrases, cat.Phrase(unit, count))
+		}
+	}
+
+	if len(phrases) == 0 {
+		return cat.Phrase(UnitSecond, 0)
+	}
+
+	s := strings.Join(phrases, " ")
+	if d.negative {
+		return string(negativeSign) + s
+	}
+
+	return s
+}
+
+// humanUnits maps the unit words and abbreviations accepted by ParseHuman to their units.
+var humanUnits = map[string]Unit{
+	"y": UnitYear, "yr": UnitYear, "yrs": UnitYear, "year": UnitYear, "years": UnitYear,
+	"mo": UnitMonth, "mos": UnitMonth, "month": UnitMonth, "months": UnitMonth,
+	"w": UnitWeek, "wk": UnitWeek, "wks": UnitWeek, "week": UnitWeek, "weeks": UnitWeek,
+	"d": UnitDay, "day": UnitDay, "days": UnitDay,
+	"h": UnitHour, "hr": UnitHour, "hrs": UnitHour, "hour": UnitHour, "hours": UnitHour,
+	"m": UnitMinute, "min": UnitMinute, "mins": UnitMinute, "minute": UnitMinute, "minutes": UnitMinute,
+	"s": UnitSecond, "sec": UnitSecond, "secs": UnitSecond, "second": UnitSecond, "seconds": UnitSecond,
+}
+
+// humanUnitSizes holds the length of each unit, years and months use the usual approximations.
+var humanUnitSizes = [...]time.Duration{
+	UnitSecond: nsPerSecond,
+	UnitMinute: nsPerMinute,
+	UnitHour:   nsPerHour,
+	UnitDay:    periodDay,
+	UnitWeek:   periodWeek,
+	UnitMonth:  periodMonth,
+	UnitYear:   periodYear,
+}
+
+// ParseHuman parses a free-form duration as typed by a person, such as "2 hours 30 minutes", "1d", "90m"
+// or "1 week, 2 days and 3h". Every number must be followed by a unit word or abbreviation, matched case-insensitively,
+// repeated units are added up. Only hours, minutes and seconds may be fractional, the clock part is carried
+// like FromTimeDurationClock, so "90m" becomes PT1H30M. Unrecognized tokens fail with ErrInvalidFormat.
+func ParseHuman(s string) (*Duration, error) {
+	var (
+		years, months, weeks, days int
+		clock                      time.Duration
+		found                      bool
+	)
+
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if field == "and" {
+			continue
+		}
+
+		n := strings.IndexFunc(field, func(r rune) bool { return !isDigit(r) && r != floatDesignator })
+		if n == 0 {
+			return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFormat, field)
+		}
+
+		number, word := field, ""
+		if n > 0 {
+			number, word = field[:n], field[n:]
+		} else if i+1 < len(fields) {
+			i++
+			word = fields[i]
+		}
+
+		// Compact forms such as 2h30m hold several components in a single field.
+		if m := strings.IndexFunc(word, isDigit); m > 0 {
+			fields[i] = word[m:]
+			word = word[:m]
+			i--
+		}
+
+		if word == "" {
+			return nil, fmt.Errorf("%w: missing unit after %s", ErrInvalidFormat, number)
+		}
+
+		unit, ok := humanUnits[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown unit %q after %s", ErrInvalidFormat, word, number)
+		}
+
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed number %q", ErrInvalidFormat, number)
+		}
+
+		if unit > UnitHour && value != math.Trunc(value) {
+			return nil, fmt.Errorf("%w: fractional %s", ErrInvalidFormat, unit)
+		}
+
+		ns := value * float64(humanUnitSizes[unit])
+		if ns > float64(maxDuration) || (unit <= UnitHour && time.Duration(ns) > maxDuration-clock) {
+			return nil, fmt.Errorf("%s %w", unit, ErrOverflow)
+		}
+
+		switch unit {
+		case UnitYear:
+			years += int(value)
+		case UnitMonth:
+			months += int(value)
+		case UnitWeek:
+			weeks += int(value)
+		case UnitDay:
+			days += int(value)
+		default:
+			clock += time.Duration(math.Round(ns))
+		}
+
+		found = true
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: no duration in %q", ErrInvalidFormat, s)
+	}
+
+	d := newDuration(false, years, months, weeks, days, clock)
+	if !d.FitsTimeDuration() {
+		return nil, fmt.Errorf("duration %w", ErrOverflow)
+	}
+
+	return d, nil
+}
+
+// ClockString renders the hours, minutes and seconds of the duration as a wall clock time of day, e.g. 14:30:00.
+// Years, months, weeks and days are ignored and the clock wraps around every 24 hours, so PT25H is 01:00:00
+// and negative durations count back from midnight, so -PT1H is 23:00:00. Fractional seconds are truncated.
+func (d *Duration) ClockString() string {
+	clock := d.clockDuration() % periodDay
+	if d.negative && clock != 0 {
+		clock = periodDay - clock
+	}
+
+	b := make([]byte, 0, 8)
+	b = appendTwoDigits(b, int(clock/nsPerHour))
+	b = append(b, ':')
+	b = appendTwoDigits(b, int(clock%nsPerHour/nsPerMinute))
+	b = append(b, ':')
+	b = appendTwoDigits(b, int(clock%nsPerMinute/nsPerSecond))
+
+	return string(b)
+}
+
+func appendTwoDigits(b []byte, n int) []byte {
+	return append(b, byte('0'+n/10), byte('0'+n%10))
+}
+
+// BestUnitLabel returns the duration expressed in the largest standard time.Duration unit in which
+// its absolute value is at least 1, together with the lowercase plural unit name, e.g. (1.5, "hours") for PT1H30M.
+// Units range from hours down to nanoseconds, a zero duration returns (0, "nanoseconds").
+func (d *Duration) BestUnitLabel() (value float64, unit string) {
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{time.Hour, "hours"},
+		{time.Minute, "minutes"},
+		{time.Second, "seconds"},
+		{time.Millisecond, "milliseconds"},
+		{time.Microsecond, "microseconds"},
+	}
+
+	td := d.GetTimeDuration()
+	for _, u := range units {
+		if d.d >= u.size {
+			return float64(td) / float64(u.size), u.name
+		}
+	}
+
+	return float64(td), "nanoseconds"
+}
+
+// Approx returns the ISO8601 duration string of at most maxComponents of the most significant non-zero
+// components, dropping the others without rounding, e.g. P1Y2M for P1Y2M3DT4H5M6S and 2.
+// A non-positive maxComponents gives PT0S.
+func (d *Duration) Approx(maxComponents int) string {
+	c := d.Components()
+	fields := [...]*int{&c.Years, &c.Months, &c.Weeks, &c.Days, &c.Hours, &c.Minutes}
+
+	kept := 0
+	for _, f := range fields {
+		if *f == 0 {
+			continue
+		}
+
+		if kept >= maxComponents {
+			*f = 0
+		}
+
+		kept++
+	}
+
+	if c.Seconds != 0 && kept >= maxComponents {
+		c.Seconds = 0
+	}
+
+	return FromComponents(c).String()
+}
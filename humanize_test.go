@@ -0,0 +1,221 @@
+package durago
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestDuration_Ago(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected string
+	}{
+		{Duration: "PT0S", Expected: "just now"},
+		{Duration: "PT59.9S", Expected: "just now"},
+		{Duration: "-PT30S", Expected: "just now"},
+		{Duration: "PT1M", Expected: "1 minute ago"},
+		{Duration: "PT5M30S", Expected: "5 minutes ago"},
+		{Duration: "PT90S", Expected: "1 minute ago"},
+		{Duration: "PT3600S", Expected: "1 hour ago"},
+		{Duration: "PT90M", Expected: "1 hour ago"},
+		{Duration: "P3DT4H", Expected: "3 days ago"},
+		{Duration: "P1W", Expected: "1 week ago"},
+		{Duration: "P2Y11M", Expected: "2 years ago"},
+		{Duration: "-PT2H", Expected: "in 2 hours"},
+		{Duration: "-P1M", Expected: "in 1 month"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.Ago(); got != c.Expected {
+			t.Fatalf("expected %s to be %q; got %q", c.Duration, c.Expected, got)
+		}
+	}
+}
+
+type frenchCatalog struct{}
+
+func (frenchCatalog) Phrase(unit Unit, count int) string {
+	names := map[Unit]string{
+		UnitYear:   "an",
+		UnitMonth:  "mois",
+		UnitWeek:   "semaine",
+		UnitDay:    "jour",
+		UnitHour:   "heure",
+		UnitMinute: "minute",
+		UnitSecond: "seconde",
+	}
+
+	name := names[unit]
+	if count > 1 && unit != UnitMonth {
+		name += "s"
+	}
+
+	return strconv.Itoa(count) + " " + name
+}
+
+func TestDuration_HumanizeLang(t *testing.T) {
+	cases := []struct {
+		Duration string
+		English  string
+		French   string
+	}{
+		{Duration: "P2DT3H", English: "2 days 3 hours", French: "2 jours 3 heures"},
+		{Duration: "P1Y2M1W", English: "1 year 2 months 1 week", French: "1 an 2 mois 1 semaine"},
+		{Duration: "PT1M30.5S", English: "1 minute 30 seconds", French: "1 minute 30 secondes"},
+		{Duration: "-PT1H", English: "-1 hour", French: "-1 heure"},
+		{Duration: "PT0.5S", English: "0 seconds", French: "0 seconde"},
+		{Duration: "PT0S", English: "0 seconds", French: "0 seconde"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.Humanize(); got != c.English {
+			t.Fatalf("expected %s to be %q; got %q", c.Duration, c.English, got)
+		}
+
+		if got := d.HumanizeLang(frenchCatalog{}); got != c.French {
+			t.Fatalf("expected %s to be %q in French; got %q", c.Duration, c.French, got)
+		}
+	}
+}
+
+func TestParseHuman(t *testing.T) {
+	cases := []struct {
+		Input       string
+		Expected    string
+		ExpectedErr error
+	}{
+		{Input: "2 hours 30 minutes", Expected: "PT2H30M"},
+		{Input: "1d", Expected: "P1D"},
+		{Input: "90m", Expected: "PT1H30M"},
+		{Input: "1 week", Expected: "P1W"},
+		{Input: "2h30m15s", Expected: "PT2H30M15S"},
+		{Input: "1 Year, 2 Months and 3 days", Expected: "P1Y2M3D"},
+		{Input: "1.5 hours", Expected: "PT1H30M"},
+		{Input: "0.25s", Expected: "PT0.25S"},
+		{Input: "10 min 20 mins", Expected: "PT30M"},
+		{Input: "  3 WKS  ", Expected: "P3W"},
+		{Input: "", ExpectedErr: ErrInvalidFormat},
+		{Input: "and", ExpectedErr: ErrInvalidFormat},
+		{Input: "5", ExpectedErr: ErrInvalidFormat},
+		{Input: "5 fortnights", ExpectedErr: ErrInvalidFormat},
+		{Input: "hours", ExpectedErr: ErrInvalidFormat},
+		{Input: "1.5 days", ExpectedErr: ErrInvalidFormat},
+		{Input: "1.2.3h", ExpectedErr: ErrInvalidFormat},
+		{Input: "-1h", ExpectedErr: ErrInvalidFormat},
+		{Input: "300 years", ExpectedErr: ErrOverflow},
+		{Input: "2000000h 2000000h", ExpectedErr: ErrOverflow},
+	}
+
+	for _, c := range cases {
+		d, err := ParseHuman(c.Input)
+		if c.ExpectedErr != nil {
+			if !errors.Is(err, c.ExpectedErr) {
+				t.Fatalf("expected %q to fail with %v; got %v", c.Input, c.ExpectedErr, err)
+			}
+
+			continue
+		}
+
+		if err != nil || d.String() != c.Expected {
+			t.Fatalf("expected %q to be %s; got %v, %v", c.Input, c.Expected, d, err)
+		}
+	}
+}
+
+func TestDuration_ClockString(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected string
+	}{
+		{Duration: "PT0S", Expected: "00:00:00"},
+		{Duration: "PT14H30M", Expected: "14:30:00"},
+		{Duration: "PT9H5M7.9S", Expected: "09:05:07"},
+		{Duration: "P1Y2DT8H", Expected: "08:00:00"},
+		{Duration: "PT25H", Expected: "01:00:00"},
+		{Duration: "PT90M", Expected: "01:30:00"},
+		{Duration: "PT24H", Expected: "00:00:00"},
+		{Duration: "-PT1H", Expected: "23:00:00"},
+		{Duration: "-PT0.5S", Expected: "23:59:59"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.ClockString(); got != c.Expected {
+			t.Fatalf("expected %s clock to be %s; got %s", c.Duration, c.Expected, got)
+		}
+	}
+}
+
+func TestDuration_BestUnitLabel(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Value    float64
+		Unit     string
+	}{
+		{Duration: "PT0S", Value: 0, Unit: "nanoseconds"},
+		{Duration: "PT1H30M", Value: 1.5, Unit: "hours"},
+		{Duration: "P1D", Value: 24, Unit: "hours"},
+		{Duration: "PT90S", Value: 1.5, Unit: "minutes"},
+		{Duration: "PT59.5S", Value: 59.5, Unit: "seconds"},
+		{Duration: "PT0.25S", Value: 250, Unit: "milliseconds"},
+		{Duration: "PT0.0000015S", Value: 1.5, Unit: "microseconds"},
+		{Duration: "PT0.000000999S", Value: 999, Unit: "nanoseconds"},
+		{Duration: "-PT1H30M", Value: -1.5, Unit: "hours"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		value, unit := d.BestUnitLabel()
+		if value != c.Value || unit != c.Unit {
+			t.Fatalf("expected %s to be (%v, %s); got (%v, %s)", c.Duration, c.Value, c.Unit, value, unit)
+		}
+	}
+}
+
+func TestDuration_Approx(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Max      int
+		Expected string
+	}{
+		{Duration: "P1Y2M3DT4H5M6S", Max: 2, Expected: "P1Y2M"},
+		{Duration: "P1Y2M3DT4H5M6S", Max: 4, Expected: "P1Y2M3DT4H"},
+		{Duration: "P1Y2M3DT4H5M6S", Max: 7, Expected: "P1Y2M3DT4H5M6S"},
+		{Duration: "P1YT59M59.9S", Max: 1, Expected: "P1Y"},
+		{Duration: "-P3DT5.5S", Max: 2, Expected: "-P3DT5.5S"},
+		{Duration: "PT1M30S", Max: 1, Expected: "PT1M"},
+		{Duration: "PT1M30S", Max: 0, Expected: "PT0S"},
+		{Duration: "PT1M30S", Max: -1, Expected: "PT0S"},
+		{Duration: "PT0S", Max: 3, Expected: "PT0S"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.Approx(c.Max); got != c.Expected {
+			t.Fatalf("expected %s approximated to %d components to be %s; got %s", c.Duration, c.Max, c.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package durago
+
+import (
+	"math"
+	"time"
+)
+
+// LengthModel defines how many days a year and a month last when converting between
+// calendar components and a time.Duration, e.g. the 30/360 day count convention used in finance.
+type LengthModel struct {
+	YearDays  float64
+	MonthDays float64
+}
+
+// DefaultLengthModel matches the approximation used by ParseDuration, FromTimeDuration and GetTimeDuration,
+// a year of 365 days and a month of a twelfth of it. It isn't used internally, but changing it
+// while other goroutines read it is a data race.
+var DefaultLengthModel = LengthModel{YearDays: 365, MonthDays: 365.0 / 12}
+
+// GetTimeDurationModel returns the time.Duration with corresponding sign,
+// converting years and months with the lengths of the given model.
+func (d *Duration) GetTimeDurationModel(m LengthModel) time.Duration {
+	td := time.Duration(d.years)*m.year() + time.Duration(d.months)*m.month() +
+		time.Duration(d.weeks)*periodWeek + time.Duration(d.days)*periodDay + d.clockDuration()
+
+	if d.negative {
+		return -td
+	}
+
+	return td
+}
+
+// FromTimeDurationModel converts the given time.Duration into durago.Duration like FromTimeDuration,
+// using the year and month lengths of the given model.
+func FromTimeDurationModel(d time.Duration, m LengthModel) *Duration {
+	negative := d < 0
+	if negative {
+		d = -d
+	}
+
+	year, month := m.year(), m.month()
+
+	var years, months int
+	if year > 0 {
+		years = int(d / year)
+		d -= time.Duration(years) * year
+	}
+
+	if month > 0 {
+		months = int(d / month)
+		d -= time.Duration(months) * month
+	}
+
+	weeks := int(d / periodWeek)
+	d -= time.Duration(weeks) * periodWeek
+
+	days := int(d / periodDay)
+	d -= time.Duration(days) * periodDay
+
+	return newDuration(negative, years, months, weeks, days, d)
+}
+
+func (m LengthModel) year() time.Duration {
+	return time.Duration(math.Round(m.YearDays * periodDay))
+}
+
+func (m LengthModel) month() time.Duration {
+	return time.Duration(math.Round(m.MonthDays * periodDay))
+}
@@ -0,0 +1,71 @@
+package durago
+
+import (
+	"testing"
+	"time"
+)
+
+var thirtyThreeSixty = LengthModel{YearDays: 360, MonthDays: 30}
+
+func TestDefaultLengthModel(t *testing.T) {
+	if DefaultLengthModel.year() != periodYear {
+		t.Fatalf("expected default year %d; got %d", time.Duration(periodYear), DefaultLengthModel.year())
+	}
+
+	if DefaultLengthModel.month() != periodMonth {
+		t.Fatalf("expected default month %d; got %d", time.Duration(periodMonth), DefaultLengthModel.month())
+	}
+}
+
+func TestDuration_GetTimeDurationModel(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Model    LengthModel
+		Expected time.Duration
+	}{
+		{Duration: "P1Y2M3DT4H", Model: DefaultLengthModel, Expected: timeYear + timeMonth*2 + timeDay*3 + time.Hour*4},
+		{Duration: "P1Y", Model: thirtyThreeSixty, Expected: timeDay * 360},
+		{Duration: "-P1M1WT1S", Model: thirtyThreeSixty, Expected: -(timeDay*37 + time.Second)},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.GetTimeDurationModel(c.Model); got != c.Expected {
+			t.Fatalf("expected %s to be %d; got %d", c.Duration, c.Expected, got)
+		}
+	}
+}
+
+func TestFromTimeDurationModel(t *testing.T) {
+	cases := []struct {
+		Duration time.Duration
+		Model    LengthModel
+		Expected string
+	}{
+		{Duration: timeDay * 360, Model: thirtyThreeSixty, Expected: "P1Y"},
+		{Duration: timeDay*400 + time.Second, Model: thirtyThreeSixty, Expected: "P1Y1M1W3DT1S"},
+		{Duration: -timeDay * 45, Model: thirtyThreeSixty, Expected: "-P1M2W1D"},
+		{Duration: time.Hour * 5, Model: LengthModel{}, Expected: "PT5H"},
+		{Duration: timeDay * 400, Model: LengthModel{}, Expected: "P57W1D"},
+	}
+
+	for _, c := range cases {
+		got := FromTimeDurationModel(c.Duration, c.Model)
+		if got.String() != c.Expected {
+			t.Fatalf("expected duration %s; got %s", c.Expected, got)
+		}
+
+		if back := got.GetTimeDurationModel(c.Model); back != c.Duration {
+			t.Fatalf("expected %s to convert back to %d; got %d", got, c.Duration, back)
+		}
+	}
+
+	d := timeYear + timeMonth + timeWeek + timeDay + time.Hour + time.Minute + time.Second + time.Millisecond*500
+	if got := FromTimeDurationModel(d, DefaultLengthModel); got.String() != FromTimeDuration(d).String() {
+		t.Fatalf("expected default model to match FromTimeDuration %s; got %s", FromTimeDuration(d), got)
+	}
+}
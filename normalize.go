@@ -0,0 +1,55 @@
+package durago
+
+// Normalize returns a copy of the duration with every component carried over into the next larger one,
+// e.g. PT90M becomes PT1H30M and P14M becomes P1Y2M. Weeks are folded into days, see NormalizeOpts.
+// Days are never carried into months and hours are carried into days of exactly 24 hours.
+func (d *Duration) Normalize() *Duration {
+	return d.NormalizeOpts(false)
+}
+
+// IsNormalized reports whether all components are within their natural ranges:
+// seconds and minutes below 60, hours below 24, months below 12 and, if weeks are used, days below 7.
+// It allows validating input such as P70M without forcing Normalize on it.
+func (d *Duration) IsNormalized() bool {
+	return d.seconds < 60 && d.minutes < 60 && d.hours < 24 && d.months < 12 && (d.weeks == 0 || d.days < 7)
+}
+
+// NormalizeOpts works like Normalize, but when useWeeks is true as many whole weeks as possible
+// are extracted from the days, e.g. P10D becomes P1W3D. When false weeks are folded into days, e.g. P2W becomes P14D.
+func (d *Duration) NormalizeOpts(useWeeks bool) *Duration {
+	clock := d.clockDuration()
+
+	days := d.weeks*7 + d.days + int(clock/periodDay)
+	clock %= periodDay
+
+	var weeks int
+	if useWeeks {
+		weeks = days / 7
+		days %= 7
+	}
+
+	return newDuration(d.negative, d.years+d.months/12, d.months%12, weeks, days, clock)
+}
+
+// Key returns a canonical string of the wall-clock value for use as a map key, so two durations produce the
+// same key exactly when Compare reports them equal, e.g. P12M and P1Y, PT24H and P1D or P365D and P1Y.
+// The value is normalized like FromTimeDuration before it's stringified, so P30D and P1M stay distinct.
+// Use EquivalentTo to tell calendar durations such as P1D and PT24H apart.
+func (d *Duration) Key() string {
+	return FromTimeDuration(d.GetTimeDuration()).String()
+}
+
+// EquivalentTo reports whether d and other denote the same calendar duration: the sign, the total of months
+// with years counted as 12, the total of days with weeks counted as 7 and the exact clock time must be equal.
+// So P1W equals P7D, P1Y equals P12M and PT1H equals PT60M, but P1M and P30D or P1D and PT24H differ,
+// as their length depends on the calendar. Two zero durations are equivalent regardless of the sign.
+func (d *Duration) EquivalentTo(other *Duration) bool {
+	if d.d == 0 && other.d == 0 {
+		return true
+	}
+
+	return d.negative == other.negative &&
+		d.years*12+d.months == other.years*12+other.months &&
+		d.weeks*7+d.days == other.weeks*7+other.days &&
+		d.clockDuration() == other.clockDuration()
+}
@@ -0,0 +1,167 @@
+package durago
+
+import "testing"
+
+func TestDuration_NormalizeOpts(t *testing.T) {
+	cases := []struct {
+		Duration string
+		UseWeeks bool
+		Expected string
+	}{
+		{Duration: "P10D", UseWeeks: true, Expected: "P1W3D"},
+		{Duration: "P10D", UseWeeks: false, Expected: "P10D"},
+		{Duration: "P2W", UseWeeks: false, Expected: "P14D"},
+		{Duration: "P2W", UseWeeks: true, Expected: "P2W"},
+		{Duration: "P1W8D", UseWeeks: true, Expected: "P2W1D"},
+		{Duration: "P6DT48H", UseWeeks: true, Expected: "P1W1D"},
+		{Duration: "P70M", UseWeeks: false, Expected: "P5Y10M"},
+		{Duration: "PT90M", UseWeeks: false, Expected: "PT1H30M"},
+		{Duration: "PT3661.5S", UseWeeks: false, Expected: "PT1H1M1.5S"},
+		{Duration: "-PT25H", UseWeeks: false, Expected: "-P1DT1H"},
+		{Duration: "P40D", UseWeeks: false, Expected: "P40D"},
+		{Duration: "PT0S", UseWeeks: true, Expected: "PT0S"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		got := d.NormalizeOpts(c.UseWeeks)
+		if got.String() != c.Expected {
+			t.Fatalf("expected %s normalized with weeks %t to be %s; got %s", c.Duration, c.UseWeeks, c.Expected, got)
+		}
+
+		if got.GetTimeDuration() != d.GetTimeDuration() {
+			t.Fatalf("expected normalizing %s to keep duration %d; got %d", c.Duration, d.GetTimeDuration(), got.GetTimeDuration())
+		}
+	}
+}
+
+func TestDuration_Normalize(t *testing.T) {
+	d, err := ParseDuration("P13M1W2DT30H")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	if got := d.Normalize(); got.String() != "P1Y1M10DT6H" {
+		t.Fatalf("expected duration P1Y1M10DT6H; got %s", got)
+	}
+
+	if d.String() != "P13M1W2DT30H" {
+		t.Fatalf("expected receiver to be unchanged; got %s", d)
+	}
+}
+
+func TestDuration_IsNormalized(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected bool
+	}{
+		{Duration: "PT0S", Expected: true},
+		{Duration: "P5Y11M30DT23H59M59.999S", Expected: true},
+		{Duration: "P1W6D", Expected: true},
+		{Duration: "P1W7D", Expected: false},
+		{Duration: "P12M", Expected: false},
+		{Duration: "P70M", Expected: false},
+		{Duration: "PT24H", Expected: false},
+		{Duration: "PT60M", Expected: false},
+		{Duration: "PT60S", Expected: false},
+		{Duration: "PT59.9S", Expected: true},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.IsNormalized(); got != c.Expected {
+			t.Fatalf("expected %s normalized to be %t; got %t", c.Duration, c.Expected, got)
+		}
+
+		if !d.Normalize().IsNormalized() {
+			t.Fatalf("expected %s to be normalized after Normalize", d.Normalize())
+		}
+	}
+}
+
+func TestDuration_Key(t *testing.T) {
+	cases := []struct {
+		A, B  string
+		Equal bool
+	}{
+		{A: "P1Y", B: "P12M", Equal: true},
+		{A: "PT24H", B: "P1D", Equal: true},
+		{A: "P365D", B: "P1Y", Equal: true},
+		{A: "P2W", B: "P14D", Equal: true},
+		{A: "P1Y2M1W", B: "P14M7D", Equal: true},
+		{A: "PT90M", B: "PT1H30M", Equal: true},
+		{A: "PT0S", B: "-PT0S", Equal: true},
+		{A: "P0D", B: "PT0S", Equal: true},
+		{A: "-PT60M", B: "-PT1H", Equal: true},
+		{A: "PT1H", B: "-PT1H", Equal: false},
+		{A: "P30D", B: "P1M", Equal: false},
+	}
+
+	for _, c := range cases {
+		a, err := ParseDuration(c.A)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		b, err := ParseDuration(c.B)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if (a.Key() == b.Key()) != c.Equal {
+			t.Fatalf("expected keys of %s and %s to be equal %t; got %s and %s", c.A, c.B, c.Equal, a.Key(), b.Key())
+		}
+
+		if (a.Compare(b) == 0) != c.Equal {
+			t.Fatalf("expected %s and %s to compare equal %t like their keys", c.A, c.B, c.Equal)
+		}
+	}
+}
+
+func TestDuration_EquivalentTo(t *testing.T) {
+	cases := []struct {
+		A, B     string
+		Expected bool
+	}{
+		{A: "P1W", B: "P7D", Expected: true},
+		{A: "P1Y", B: "P12M", Expected: true},
+		{A: "P1Y1W", B: "P11M30DT0S", Expected: false},
+		{A: "P1Y2M1W", B: "P14M7D", Expected: true},
+		{A: "PT1H", B: "PT60M", Expected: true},
+		{A: "PT1M", B: "PT60S", Expected: true},
+		{A: "PT0S", B: "-P0D", Expected: true},
+		{A: "P1M", B: "P30D", Expected: false},
+		{A: "P1D", B: "PT24H", Expected: false},
+		{A: "P1Y", B: "P365D", Expected: false},
+		{A: "P1W", B: "-P7D", Expected: false},
+		{A: "PT1.5S", B: "PT1.500000001S", Expected: false},
+	}
+
+	for _, c := range cases {
+		a, err := ParseDuration(c.A)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		b, err := ParseDuration(c.B)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := a.EquivalentTo(b); got != c.Expected {
+			t.Fatalf("expected %s equivalent to %s to be %t; got %t", c.A, c.B, c.Expected, got)
+		}
+
+		if got := b.EquivalentTo(a); got != c.Expected {
+			t.Fatalf("expected %s equivalent to %s to be %t; got %t", c.B, c.A, c.Expected, got)
+		}
+	}
+}
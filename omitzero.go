@@ -0,0 +1,21 @@
+package durago
+
+// OmitZeroDuration marshals a Duration to JSON like Duration does, except that a zero duration becomes null
+// instead of "PT0S", giving unset durations clean JSON semantics. Unmarshaling null results in a zero duration.
+// Convert with OmitZeroDuration(*d) and (*Duration)(&o).
+type OmitZeroDuration Duration
+
+// MarshalJSON satisfies the Marshaler interface by returning null for a zero duration and the ISO8601 string otherwise.
+func (o OmitZeroDuration) MarshalJSON() ([]byte, error) {
+	if o.d == 0 {
+		return []byte("null"), nil
+	}
+
+	return Duration(o).MarshalJSON()
+}
+
+// UnmarshalJSON satisfies the Unmarshaler interface, accepting the same input as Duration,
+// so null results in a zero duration.
+func (o *OmitZeroDuration) UnmarshalJSON(b []byte) error {
+	return (*Duration)(o).UnmarshalJSON(b)
+}
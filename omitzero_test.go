@@ -0,0 +1,62 @@
+package durago
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOmitZeroDuration_MarshalJSON(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected string
+	}{
+		{Duration: "PT0S", Expected: `{"timeout":null}`},
+		{Duration: "-P0D", Expected: `{"timeout":null}`},
+		{Duration: "PT1H30M", Expected: `{"timeout":"PT1H30M"}`},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		b, err := json.Marshal(struct {
+			Timeout OmitZeroDuration `json:"timeout"`
+		}{OmitZeroDuration(*d)})
+		if err != nil || string(b) != c.Expected {
+			t.Fatalf("expected %s to marshal as %s; got %s, %v", c.Duration, c.Expected, b, err)
+		}
+	}
+
+	b, _ := json.Marshal(Duration{})
+	if string(b) != `"PT0S"` {
+		t.Fatalf(`expected Duration to keep marshaling zero as "PT0S"; got %s`, b)
+	}
+}
+
+func TestOmitZeroDuration_UnmarshalJSON(t *testing.T) {
+	var v struct {
+		Timeout OmitZeroDuration `json:"timeout"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"timeout":"PT5M"}`), &v); err != nil {
+		t.Fatalf("expected no error; got %v", err)
+	}
+
+	if d := Duration(v.Timeout); d.String() != "PT5M" {
+		t.Fatalf("expected duration PT5M; got %s", &d)
+	}
+
+	if err := json.Unmarshal([]byte(`{"timeout":null}`), &v); err != nil {
+		t.Fatalf("expected no error; got %v", err)
+	}
+
+	if d := Duration(v.Timeout); !d.IsZero() {
+		t.Fatalf("expected null to reset the duration; got %s", &d)
+	}
+
+	if err := json.Unmarshal([]byte(`{"timeout":"P1X"}`), &v); err == nil {
+		t.Fatalf("expected invalid duration to fail")
+	}
+}
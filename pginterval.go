@@ -0,0 +1,161 @@
+package durago
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PGInterval stores a Duration in a native Postgres interval column. It reads and writes the default
+// postgres IntervalStyle, e.g. 1 year 2 mons 3 days 04:05:06, instead of ISO8601.
+// Convert with PGInterval(*d) and (*Duration)(&p).
+type PGInterval Duration
+
+// Value satisfies the driver.Valuer interface by returning the Postgres interval representation.
+// Weeks are written as days and the clock components are normalized into hh:mm:ss.
+func (p PGInterval) Value() (driver.Value, error) {
+	d := Duration(p)
+
+	var b strings.Builder
+
+	sign := ""
+	if d.negative {
+		sign = "-"
+	}
+
+	fields := []struct {
+		value int
+		unit  string
+	}{
+		{d.years, "year"},
+		{d.months, "mon"},
+		{d.weeks*7 + d.days, "day"},
+	}
+
+	for _, f := range fields {
+		if f.value == 0 {
+			continue
+		}
+
+		b.WriteString(sign)
+		b.WriteString(pluralize(f.value, f.unit))
+		b.WriteByte(' ')
+	}
+
+	clock := d.clockDuration()
+	if clock != 0 || b.Len() == 0 {
+		if clock != 0 {
+			b.WriteString(sign)
+		}
+
+		fmt.Fprintf(&b, "%02d:%02d:%02d", clock/nsPerHour, clock%nsPerHour/nsPerMinute, clock%nsPerMinute/nsPerSecond)
+
+		if frac := clock % nsPerSecond; frac != 0 {
+			b.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", frac), "0"))
+		}
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
+
+// Scan satisfies the sql.Scanner interface by parsing the Postgres interval representation.
+// Postgres allows a different sign for each field; as ISO8601 doesn't, such intervals are combined like Sum does.
+// A NULL value results in a zero duration.
+func (p *PGInterval) Scan(src any) error {
+	var s string
+
+	switch v := src.(type) {
+	case nil:
+		*p = PGInterval{}
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("%w: unsupported interval type %T", ErrInvalidFormat, src)
+	}
+
+	parts := make([]*Duration, 0, 4)
+	tokens := strings.Fields(s)
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		if strings.Contains(token, ":") {
+			part, err := parsePGClock(token)
+			if err != nil {
+				return err
+			}
+
+			parts = append(parts, part)
+			continue
+		}
+
+		if i+1 >= len(tokens) {
+			return fmt.Errorf("%w: missing interval unit after %s", ErrInvalidFormat, token)
+		}
+
+		value, err := strconv.Atoi(token)
+		if err != nil {
+			return fmt.Errorf("interval %w: %s", ErrParse, err.Error())
+		}
+
+		part := &Duration{negative: value < 0}
+		if value < 0 {
+			value = -value
+		}
+
+		i++
+		switch strings.TrimSuffix(tokens[i], "s") {
+		case "year":
+			part.years = value
+			part.d = time.Duration(value) * periodYear
+		case "mon":
+			part.months = value
+			part.d = time.Duration(value) * periodMonth
+		case "day":
+			part.days = value
+			part.d = time.Duration(value) * periodDay
+		default:
+			return fmt.Errorf("%w: unexpected interval unit %s", ErrInvalidFormat, tokens[i])
+		}
+
+		parts = append(parts, part)
+	}
+
+	*p = PGInterval(*Sum(parts...))
+	return nil
+}
+
+// parsePGClock parses the [-]hh:mm:ss[.ffffff] part of a Postgres interval.
+func parsePGClock(token string) (*Duration, error) {
+	negative := strings.HasPrefix(token, "-")
+	token = strings.TrimLeft(token, "+-")
+
+	fields := strings.Split(token, ":")
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("%w: unexpected interval time %s", ErrInvalidFormat, token)
+	}
+
+	hours, err := strconv.ParseUint(fields[0], 10, 63)
+	if err != nil {
+		return nil, fmt.Errorf("hour %w: %s", ErrParse, err.Error())
+	}
+
+	minutes, err := strconv.ParseUint(fields[1], 10, 63)
+	if err != nil {
+		return nil, fmt.Errorf("minute %w: %s", ErrParse, err.Error())
+	}
+
+	_, seconds, err := parseSeconds(fields[2])
+	if err != nil || seconds < 0 {
+		return nil, fmt.Errorf("%w: unexpected interval seconds %s", ErrInvalidFormat, fields[2])
+	}
+
+	clock := time.Duration(hours)*nsPerHour + time.Duration(minutes)*nsPerMinute + seconds
+
+	return newDuration(negative, 0, 0, 0, 0, clock), nil
+}
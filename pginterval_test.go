@@ -0,0 +1,104 @@
+package durago
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ driver.Valuer = PGInterval{}
+	_ sql.Scanner   = &PGInterval{}
+)
+
+func TestPGInterval_Value(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected string
+	}{
+		{Duration: "PT0S", Expected: "00:00:00"},
+		{Duration: "P1Y2M3DT4H5M6S", Expected: "1 year 2 mons 3 days 04:05:06"},
+		{Duration: "P2Y1M1D", Expected: "2 years 1 mon 1 day"},
+		{Duration: "P1W2D", Expected: "9 days"},
+		{Duration: "PT90M0.25S", Expected: "01:30:00.25"},
+		{Duration: "PT100H", Expected: "100:00:00"},
+		{Duration: "-P1DT1H", Expected: "-1 day -01:00:00"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		got, err := PGInterval(*d).Value()
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+
+		if got != c.Expected {
+			t.Fatalf("expected %s to be %q; got %q", c.Duration, c.Expected, got)
+		}
+	}
+}
+
+func TestPGInterval_Scan(t *testing.T) {
+	cases := []struct {
+		Source      any
+		Expected    string
+		ExpectedErr bool
+	}{
+		{Source: nil, Expected: "PT0S"},
+		{Source: "00:00:00", Expected: "PT0S"},
+		{Source: "1 year 2 mons 3 days 04:05:06", Expected: "P1Y2M3DT4H5M6S"},
+		{Source: []byte("2 years 1 mon 1 day"), Expected: "P2Y1M1D"},
+		{Source: "01:30:00.25", Expected: "PT1H30M0.25S"},
+		{Source: "100:00:00", Expected: "PT100H"},
+		{Source: "-1 days -01:00:00", Expected: "-P1DT1H"},
+		{Source: "1 day -01:00:00", Expected: "PT23H"},
+		{Source: "1 fortnight", ExpectedErr: true},
+		{Source: "1 year 2", ExpectedErr: true},
+		{Source: "01:00", ExpectedErr: true},
+		{Source: 42, ExpectedErr: true},
+	}
+
+	for _, c := range cases {
+		var p PGInterval
+
+		err := p.Scan(c.Source)
+		if c.ExpectedErr {
+			if err == nil {
+				t.Fatalf("expected error scanning %v", c.Source)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected err scanning %v: %v", c.Source, err)
+		}
+
+		if got := (*Duration)(&p); got.String() != c.Expected {
+			t.Fatalf("expected %v to be %s; got %s", c.Source, c.Expected, got)
+		}
+	}
+}
+
+func TestPGInterval_RoundTrip(t *testing.T) {
+	for _, c := range []string{"P1Y2M3DT4H5M6.5S", "-P3M", "PT12H", "PT0S"} {
+		d, _ := ParseDuration(c)
+
+		v, err := PGInterval(*d).Value()
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+
+		var p PGInterval
+		if err := p.Scan(v); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+
+		if got := (*Duration)(&p); got.String() != c {
+			t.Fatalf("expected round trip of %s; got %s", c, got)
+		}
+	}
+}
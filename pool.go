@@ -0,0 +1,38 @@
+package durago
+
+import "sync"
+
+var durationPool = sync.Pool{
+	New: func() any {
+		return new(Duration)
+	},
+}
+
+// AcquireDuration returns a zero *Duration from a pool, to be handed back with ReleaseDuration
+// once it's no longer needed. It's meant for hot paths parsing many short-lived durations.
+// AcquireDuration and ReleaseDuration are safe for concurrent use, the returned duration belongs to the caller alone.
+func AcquireDuration() *Duration {
+	return durationPool.Get().(*Duration)
+}
+
+// ReleaseDuration resets d and returns it to the pool used by AcquireDuration.
+// Neither d nor anything still referencing it may be used after the call. A nil d is ignored.
+func ReleaseDuration(d *Duration) {
+	if d == nil {
+		return
+	}
+
+	*d = Duration{}
+	durationPool.Put(d)
+}
+
+// ParseDurationInto works like ParseDuration but stores the result in dst instead of allocating a new *Duration,
+// which pairs with AcquireDuration. On error dst is left unchanged.
+func ParseDurationInto(dst *Duration, s string) error {
+	_, pos, err := parseDurationInto(dst, s, 0)
+	if err != nil {
+		return newParseError(s, pos, err)
+	}
+
+	return nil
+}
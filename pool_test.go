@@ -0,0 +1,98 @@
+package durago
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestParseDurationInto(t *testing.T) {
+	d := AcquireDuration()
+	defer ReleaseDuration(d)
+
+	if err := ParseDurationInto(d, "P1DT2H"); err != nil {
+		t.Fatalf("expected no error; got %v", err)
+	}
+
+	if d.String() != "P1DT2H" {
+		t.Fatalf("expected duration P1DT2H; got %s", d)
+	}
+
+	if err := ParseDurationInto(d, "P1X"); !errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("expected ErrInvalidFormat; got %v", err)
+	}
+
+	if d.String() != "P1DT2H" {
+		t.Fatalf("expected failed parse to leave P1DT2H; got %s", d)
+	}
+}
+
+func TestReleaseDuration(t *testing.T) {
+	d := AcquireDuration()
+	if err := ParseDurationInto(d, "-PT5M"); err != nil {
+		t.Fatalf("expected no error; got %v", err)
+	}
+
+	ReleaseDuration(d)
+	ReleaseDuration(nil)
+
+	if !d.IsZero() || d.negative {
+		t.Fatalf("expected released duration to be reset; got %s", d)
+	}
+}
+
+func TestParseDurationInto_Concurrent(t *testing.T) {
+	inputs := []string{"P1DT2H", "-PT30M", "PT0.5S", "P2W"}
+
+	var wg sync.WaitGroup
+	var failed sync.Once
+
+	for g := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for n := range 200 {
+				input := inputs[(g+n)%len(inputs)]
+
+				d := AcquireDuration()
+				err := ParseDurationInto(d, input)
+				got := d.String()
+				ReleaseDuration(d)
+
+				if err != nil || got != input {
+					failed.Do(func() {
+						t.Errorf("expected pooled parse of %s to give %s; got %s, %v", input, input, got, err)
+					})
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestParseDurationInto_Allocations(t *testing.T) {
+	var d Duration
+
+	allocs := testing.AllocsPerRun(100, func() {
+		ParseDurationInto(&d, "P3Y6M1W4DT12H30M5.5S")
+	})
+
+	if allocs != 0 {
+		t.Fatalf("expected no allocations; got %v", allocs)
+	}
+}
+
+func BenchmarkParseDuration_Pooled(b *testing.B) {
+	duration := "+P3Y6M1W4DT12H30M5S"
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		d := AcquireDuration()
+		ParseDurationInto(d, duration)
+		ReleaseDuration(d)
+	}
+}
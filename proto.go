@@ -0,0 +1,18 @@
+package durago
+
+import "time"
+
+// ToProtoParts returns the seconds and nanos of a google.protobuf.Duration for the duration.
+// Protobuf durations have no notion of calendar components, so years and months are converted
+// with their approximate lengths as GetTimeDuration does. Both values share the sign of the duration.
+func (d *Duration) ToProtoParts() (seconds int64, nanos int32) {
+	td := d.GetTimeDuration()
+
+	return int64(td / time.Second), int32(td % time.Second)
+}
+
+// FromProtoParts converts the seconds and nanos of a google.protobuf.Duration into a *Duration,
+// the components are derived the same way as FromTimeDuration does.
+func FromProtoParts(seconds int64, nanos int32) *Duration {
+	return FromTimeDuration(time.Duration(seconds)*time.Second + time.Duration(nanos))
+}
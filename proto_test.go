@@ -0,0 +1,51 @@
+package durago
+
+import "testing"
+
+func TestDuration_ToProtoParts(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Seconds  int64
+		Nanos    int32
+	}{
+		{Duration: "PT0S"},
+		{Duration: "PT1H30M", Seconds: 5400},
+		{Duration: "PT1.5S", Seconds: 1, Nanos: 500000000},
+		{Duration: "-PT1.5S", Seconds: -1, Nanos: -500000000},
+		{Duration: "P1D", Seconds: 86400},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		seconds, nanos := d.ToProtoParts()
+		if seconds != c.Seconds || nanos != c.Nanos {
+			t.Fatalf("expected %s to be %ds %dns; got %ds %dns", c.Duration, c.Seconds, c.Nanos, seconds, nanos)
+		}
+
+		if got := FromProtoParts(seconds, nanos); got.GetTimeDuration() != d.GetTimeDuration() {
+			t.Fatalf("expected duration %s; got %s", d, got)
+		}
+	}
+}
+
+func TestFromProtoParts(t *testing.T) {
+	cases := []struct {
+		Seconds  int64
+		Nanos    int32
+		Expected string
+	}{
+		{Expected: "PT0S"},
+		{Seconds: 90, Expected: "PT1M30S"},
+		{Seconds: -3600, Nanos: -1000000, Expected: "-PT1H0.001S"},
+	}
+
+	for _, c := range cases {
+		if got := FromProtoParts(c.Seconds, c.Nanos); got.String() != c.Expected {
+			t.Fatalf("expected duration %s; got %s", c.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package durago
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const recurringDesignator = 'R'
+
+// ParseRecurring parses an ISO8601 recurring duration such as R5/PT1H, repeating 5 times, or R/PT1H,
+// repeating without bound, in which case count is 0 and unbounded is true. The part after the slash is
+// parsed with ParseDuration. Only the R[n]/duration form is supported, not recurrences with start or end times.
+func ParseRecurring(s string) (count int, unbounded bool, d *Duration, err error) {
+	input := s
+	s = strings.TrimSpace(s)
+
+	if len(s) == 0 || s[0] != recurringDesignator {
+		return 0, false, nil, fmt.Errorf("%w: missing recurrence designator", ErrInvalidFormat)
+	}
+
+	prefix, rest, found := strings.Cut(s[1:], "/")
+	if !found {
+		return 0, false, nil, fmt.Errorf("%w: missing recurrence separator", ErrInvalidFormat)
+	}
+
+	if prefix == "" {
+		unbounded = true
+	} else {
+		if strings.IndexFunc(prefix, func(r rune) bool { return !isDigit(r) }) >= 0 {
+			return 0, false, nil, fmt.Errorf("%w: malformed recurrence count %q", ErrInvalidFormat, prefix)
+		}
+
+		count, err = strconv.Atoi(prefix)
+		if err != nil {
+			if errors.Is(err, strconv.ErrRange) {
+				return 0, false, nil, fmt.Errorf("recurrence count %w", ErrOverflow)
+			}
+
+			return 0, false, nil, fmt.Errorf("recurrence count %w: %s", ErrParse, err.Error())
+		}
+	}
+
+	if rest == "" {
+		return 0, false, nil, fmt.Errorf("%w: missing duration", ErrInvalidFormat)
+	}
+
+	d, err = ParseDuration(rest)
+	if err != nil {
+		// The duration starts after the leading whitespace, the recurrence and the separator.
+		offset := len(input) - len(strings.TrimLeftFunc(input, unicode.IsSpace)) + len(s) - len(rest)
+		return 0, false, nil, rebaseParseError(err, input, func(pos int) int { return pos + offset })
+	}
+
+	return count, unbounded, d, nil
+}
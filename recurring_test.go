@@ -0,0 +1,49 @@
+package durago
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRecurring(t *testing.T) {
+	cases := []struct {
+		Input       string
+		Count       int
+		Unbounded   bool
+		Duration    string
+		ExpectedErr error
+	}{
+		{Input: "R5/PT1H", Count: 5, Duration: "PT1H"},
+		{Input: "R/P1D", Unbounded: true, Duration: "P1D"},
+		{Input: "R0/PT30M", Count: 0, Duration: "PT30M"},
+		{Input: " R12/-P1W ", Count: 12, Duration: "-P1W"},
+		{Input: "Rx/PT1H", ExpectedErr: ErrInvalidFormat},
+		{Input: "R-1/PT1H", ExpectedErr: ErrInvalidFormat},
+		{Input: "R5PT1H", ExpectedErr: ErrInvalidFormat},
+		{Input: "PT1H", ExpectedErr: ErrInvalidFormat},
+		{Input: "", ExpectedErr: ErrInvalidFormat},
+		{Input: "R5/P1X", ExpectedErr: ErrInvalidFormat},
+		{Input: "R5/", ExpectedErr: ErrInvalidFormat},
+		{Input: "R/", ExpectedErr: ErrInvalidFormat},
+		{Input: "R99999999999999999999/PT1H", ExpectedErr: ErrOverflow},
+	}
+
+	for _, c := range cases {
+		count, unbounded, d, err := ParseRecurring(c.Input)
+		if c.ExpectedErr != nil {
+			if !errors.Is(err, c.ExpectedErr) {
+				t.Fatalf("expected %q to fail with %v; got %v", c.Input, c.ExpectedErr, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("expected to parse %q; got %v", c.Input, err)
+		}
+
+		if count != c.Count || unbounded != c.Unbounded || d.String() != c.Duration {
+			t.Fatalf("expected %q to give %d, %t, %s; got %d, %t, %s", c.Input, c.Count, c.Unbounded, c.Duration, count, unbounded, d)
+		}
+	}
+}
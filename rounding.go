@@ -0,0 +1,59 @@
+package durago
+
+import "time"
+
+// Quantize rounds the duration to a human friendly granularity picked by its magnitude:
+// durations under a minute are rounded to seconds, under an hour to minutes, under a day to hours
+// and anything longer to days. Halves are rounded away from zero, e.g. PT90S becomes PT2M
+// and PT1H2M3S becomes PT1H. Years and months are converted with their approximate lengths.
+func (d *Duration) Quantize() *Duration {
+	var unit time.Duration
+
+	switch {
+	case d.d < nsPerMinute:
+		unit = nsPerSecond
+	case d.d < nsPerHour:
+		unit = nsPerMinute
+	case d.d < periodDay:
+		unit = nsPerHour
+	default:
+		unit = periodDay
+	}
+
+	rounded := d.d.Round(unit)
+	if unit == periodDay {
+		return newDuration(d.negative, 0, 0, 0, int(rounded/periodDay), 0)
+	}
+
+	return newDuration(d.negative, 0, 0, 0, 0, rounded)
+}
+
+// CeilTo rounds the signed time.Duration value of the duration up to the next multiple of unit, unless it already
+// is one, and rebuilds it from hours, minutes and seconds, e.g. PT1M1S becomes PT2M for a unit of a minute.
+// Up means towards positive infinity, so negative durations round towards zero: -PT1M59S becomes -PT1M.
+// A non-positive unit returns an unchanged copy and results beyond MaxDuration are clamped to it.
+func (d *Duration) CeilTo(unit time.Duration) *Duration {
+	if unit <= 0 {
+		c := *d
+		return &c
+	}
+
+	v := d.GetTimeDuration()
+
+	rem := v % unit
+	if rem > 0 {
+		if v > maxDuration-(unit-rem) {
+			v = maxDuration
+		} else {
+			v += unit - rem
+		}
+	} else if rem < 0 {
+		v -= rem
+	}
+
+	if v < 0 {
+		return newDuration(true, 0, 0, 0, 0, -v)
+	}
+
+	return newDuration(false, 0, 0, 0, 0, v)
+}
@@ -0,0 +1,70 @@
+package durago
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration_Quantize(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected string
+	}{
+		{Duration: "PT0S", Expected: "PT0S"},
+		{Duration: "PT0.4S", Expected: "PT0S"},
+		{Duration: "PT12.5S", Expected: "PT13S"},
+		{Duration: "PT59.4S", Expected: "PT59S"},
+		{Duration: "PT59.5S", Expected: "PT1M"},
+		{Duration: "PT90S", Expected: "PT2M"},
+		{Duration: "PT5M29S", Expected: "PT5M"},
+		{Duration: "PT1H2M3S", Expected: "PT1H"},
+		{Duration: "PT1H30M", Expected: "PT2H"},
+		{Duration: "PT23H40M", Expected: "PT24H"},
+		{Duration: "P1DT11H", Expected: "P1D"},
+		{Duration: "P1WT12H", Expected: "P8D"},
+		{Duration: "-PT90S", Expected: "-PT2M"},
+		{Duration: "-P2DT1H", Expected: "-P2D"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.Quantize(); got.String() != c.Expected {
+			t.Fatalf("expected %s to quantize to %s; got %s", c.Duration, c.Expected, got)
+		}
+	}
+}
+
+func TestDuration_CeilTo(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Unit     time.Duration
+		Expected string
+	}{
+		{Duration: "PT1M1S", Unit: time.Minute, Expected: "PT2M"},
+		{Duration: "PT2M", Unit: time.Minute, Expected: "PT2M"},
+		{Duration: "PT0.000000001S", Unit: time.Minute, Expected: "PT1M"},
+		{Duration: "PT0S", Unit: time.Minute, Expected: "PT0S"},
+		{Duration: "-PT1M59S", Unit: time.Minute, Expected: "-PT1M"},
+		{Duration: "-PT2M", Unit: time.Minute, Expected: "-PT2M"},
+		{Duration: "-PT59S", Unit: time.Minute, Expected: "PT0S"},
+		{Duration: "P1DT1S", Unit: time.Hour, Expected: "PT25H"},
+		{Duration: "PT7M", Unit: 15 * time.Minute, Expected: "PT15M"},
+		{Duration: "PT1M1S", Unit: 0, Expected: "PT1M1S"},
+		{Duration: "P292Y5M2W5DT21H47M16S", Unit: time.Minute, Expected: "PT2562047H47M16.854775807S"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.CeilTo(c.Unit); got.String() != c.Expected {
+			t.Fatalf("expected %s rounded up to %s to be %s; got %s", c.Duration, c.Unit, c.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package durago
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrUnitNotAllowed is returned by StringForSchema when a component can't be expressed in the allowed units.
+var ErrUnitNotAllowed = errors.New("unit not allowed")
+
+// StringForSchema returns the ISO8601 duration string using only the allowed units, as required by schemas
+// that forbid some designators. Disallowed units are folded into the next smaller unit where the conversion
+// is exact: years into months, weeks into days, days into hours of 24 hours, hours into minutes and minutes into seconds.
+// Months can't be folded, so a duration with months fails with ErrUnitNotAllowed unless months are allowed.
+func (d *Duration) StringForSchema(allowed []Unit) (string, error) {
+	c := d.Components()
+
+	if c.Years != 0 && !slices.Contains(allowed, UnitYear) {
+		if !slices.Contains(allowed, UnitMonth) {
+			return "", fmt.Errorf("%w: %s", ErrUnitNotAllowed, UnitYear)
+		}
+
+		c.Months += c.Years * 12
+		c.Years = 0
+	}
+
+	if c.Months != 0 && !slices.Contains(allowed, UnitMonth) {
+		return "", fmt.Errorf("%w: %s", ErrUnitNotAllowed, UnitMonth)
+	}
+
+	if c.Weeks != 0 && !slices.Contains(allowed, UnitWeek) {
+		c.Days += c.Weeks * 7
+		c.Weeks = 0
+	}
+
+	if c.Days != 0 && !slices.Contains(allowed, UnitDay) {
+		c.Hours += c.Days * 24
+		c.Days = 0
+	}
+
+	if c.Hours != 0 && !slices.Contains(allowed, UnitHour) {
+		c.Minutes += c.Hours * 60
+		c.Hours = 0
+	}
+
+	if c.Minutes != 0 && !slices.Contains(allowed, UnitMinute) {
+		c.Seconds += float64(c.Minutes * 60)
+		c.Minutes = 0
+	}
+
+	if c.Seconds != 0 && !slices.Contains(allowed, UnitSecond) {
+		return "", fmt.Errorf("%w: %s", ErrUnitNotAllowed, UnitSecond)
+	}
+
+	if d.d != 0 || c.Seconds != 0 {
+		return FromComponents(c).String(), nil
+	}
+
+	// A zero duration is written with the smallest allowed unit, e.g. P0D when only days are allowed.
+	switch smallest := slices.Min(append([]Unit{UnitYear + 1}, allowed...)); smallest {
+	case UnitNone, UnitSecond:
+		return zeroDuration, nil
+	case UnitMinute:
+		return "PT0M", nil
+	case UnitHour:
+		return "PT0H", nil
+	case UnitDay:
+		return "P0D", nil
+	case UnitWeek:
+		return "P0W", nil
+	case UnitMonth:
+		return "P0M", nil
+	case UnitYear:
+		return "P0Y", nil
+	}
+
+	return "", fmt.Errorf("%w: no units allowed", ErrUnitNotAllowed)
+}
@@ -0,0 +1,52 @@
+package durago
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDuration_StringForSchema(t *testing.T) {
+	dateTime := []Unit{UnitYear, UnitMonth, UnitDay, UnitHour, UnitMinute, UnitSecond}
+	timeOnly := []Unit{UnitHour, UnitMinute, UnitSecond}
+
+	cases := []struct {
+		Duration    string
+		Allowed     []Unit
+		Expected    string
+		ExpectedErr error
+	}{
+		{Duration: "P1Y2M3DT4H", Allowed: dateTime, Expected: "P1Y2M3DT4H"},
+		{Duration: "P2W3D", Allowed: dateTime, Expected: "P17D"},
+		{Duration: "P1Y2M", Allowed: []Unit{UnitMonth}, Expected: "P14M"},
+		{Duration: "P1DT2H", Allowed: timeOnly, Expected: "PT26H"},
+		{Duration: "-P1W", Allowed: timeOnly, Expected: "-PT168H"},
+		{Duration: "PT1H30M", Allowed: []Unit{UnitSecond}, Expected: "PT5400S"},
+		{Duration: "PT1H30.5S", Allowed: []Unit{UnitMinute, UnitSecond}, Expected: "PT60M30.5S"},
+		{Duration: "PT0S", Allowed: []Unit{UnitDay}, Expected: "P0D"},
+		{Duration: "PT0S", Allowed: timeOnly, Expected: "PT0S"},
+		{Duration: "P1M", Allowed: timeOnly, ExpectedErr: ErrUnitNotAllowed},
+		{Duration: "P1Y", Allowed: []Unit{UnitDay}, ExpectedErr: ErrUnitNotAllowed},
+		{Duration: "PT1H1S", Allowed: []Unit{UnitHour}, ExpectedErr: ErrUnitNotAllowed},
+		{Duration: "PT0S", Allowed: nil, ExpectedErr: ErrUnitNotAllowed},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		got, err := d.StringForSchema(c.Allowed)
+		if c.ExpectedErr != nil {
+			if !errors.Is(err, c.ExpectedErr) {
+				t.Fatalf("expected %s with %v to fail with %v; got %v", c.Duration, c.Allowed, c.ExpectedErr, err)
+			}
+
+			continue
+		}
+
+		if err != nil || got != c.Expected {
+			t.Fatalf("expected %s with %v to be %s; got %s, %v", c.Duration, c.Allowed, c.Expected, got, err)
+		}
+	}
+}
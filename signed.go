@@ -0,0 +1,161 @@
+package durago
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SignedDuration is an ISO 8601-2 duration whose components carry their own sign, e.g. PT-1H30M.
+// It's kept apart from Duration, which only supports a single leading sign; use Duration to fold it into one.
+type SignedDuration struct {
+	magnitude Duration
+	negated   uint8 // bit 1<<Unit is set for the components written with a negative sign
+}
+
+// ParseDurationSigned parses an ISO 8601-2 duration whose components may carry their own negative sign,
+// e.g. PT-1H30M for minus one hour plus thirty minutes. A leading sign still negates the whole duration.
+// ParseDuration rejects such input.
+//
+// The result is a *SignedDuration rather than a *Duration with a StringSigned method, so that a Duration never
+// carries component signs that its String, MarshalJSON and arithmetic can't represent. SignedDuration.String
+// formats the signs back, e.g. PT-1H30M, and SignedDuration.Duration folds them into an ordinary Duration.
+func ParseDurationSigned(d string) (*SignedDuration, error) {
+	input := d
+	d = strings.TrimSpace(d)
+
+	plain := make([]byte, 0, len(d))
+	var negated uint8
+	// stripped holds the indexes of the component signs removed from d, to report errors against input.
+	var stripped []int
+	pending := false
+	timePart := false
+
+	for i := 0; i < len(d); i++ {
+		char := d[i]
+
+		// A component sign directly follows a designator and precedes the digits of the value.
+		if char == negativeSign && i > 0 && isComponentStart(d[i-1]) && i+1 < len(d) && isDigit(rune(d[i+1])) {
+			pending = true
+			stripped = append(stripped, i)
+			continue
+		}
+
+		if char == timeDesignator {
+			timePart = true
+		}
+
+		if pending && !isDigit(rune(char)) && char != floatDesignator {
+			negated |= 1 << designatorUnit(char, timePart)
+			pending = false
+		}
+
+		plain = append(plain, d[i])
+	}
+
+	duration, err := ParseDuration(string(plain))
+	if err != nil {
+		lead := len(input) - len(strings.TrimLeftFunc(input, unicode.IsSpace))
+		return nil, rebaseParseError(err, input, func(pos int) int {
+			for _, i := range stripped {
+				if i <= pos {
+					pos++
+				}
+			}
+
+			return lead + pos
+		})
+	}
+
+	return &SignedDuration{magnitude: *duration, negated: negated}, nil
+}
+
+// String returns the ISO 8601-2 duration string with every component formatted with its own sign, e.g. PT-1H30M.
+func (s *SignedDuration) String() string {
+	if !s.magnitude.hasCalendar() && !s.magnitude.hasClock() {
+		return zeroDuration
+	}
+
+	v := s.magnitude
+	v.years *= s.sign(UnitYear)
+	v.months *= s.sign(UnitMonth)
+	v.weeks *= s.sign(UnitWeek)
+	v.days *= s.sign(UnitDay)
+	v.hours *= s.sign(UnitHour)
+	v.minutes *= s.sign(UnitMinute)
+	v.seconds *= float64(s.sign(UnitSecond))
+
+	return string(v.appendComponents(make([]byte, 0, 20), -1, 0))
+}
+
+// GetTimeDuration returns the signed sum of the components as time.Duration.
+func (s *SignedDuration) GetTimeDuration() time.Duration {
+	var total time.Duration
+	for _, c := range s.magnitude.intComponents() {
+		total += time.Duration(c.value*s.sign(c.unit)) * c.size
+	}
+
+	total += time.Duration(s.sign(UnitSecond)) * s.magnitude.secondsDuration()
+
+	if s.magnitude.negative {
+		return -total
+	}
+
+	return total
+}
+
+// Duration folds the signed components into an ordinary Duration.
+// The components are kept when they all share a sign, mixed signs are summed up like FromTimeDuration.
+func (s *SignedDuration) Duration() *Duration {
+	v := s.magnitude
+
+	switch mask := v.nonZeroUnits(); s.negated & mask {
+	case 0:
+		return &v
+	case mask:
+		v.negative = !v.negative
+		return &v
+	}
+
+	return FromTimeDuration(s.GetTimeDuration())
+}
+
+// sign returns -1 for a component written with a negative sign and 1 otherwise.
+func (s *SignedDuration) sign(unit Unit) int {
+	if s.negated&(1<<unit) != 0 {
+		return -1
+	}
+
+	return 1
+}
+
+// designatorUnit returns the unit of a component designator, minutes share theirs with months.
+func designatorUnit(char byte, timePart bool) Unit {
+	switch char {
+	case yearDesignator:
+		return UnitYear
+	case minuteMonthDesignator:
+		if timePart {
+			return UnitMinute
+		}
+
+		return UnitMonth
+	case weekDesignator:
+		return UnitWeek
+	case dayDesignator:
+		return UnitDay
+	case hourDesignator:
+		return UnitHour
+	}
+
+	return UnitSecond
+}
+
+func isComponentStart(char byte) bool {
+	switch char {
+	case durationDesignator, timeDesignator, yearDesignator, minuteMonthDesignator, weekDesignator, dayDesignator, hourDesignator:
+		return true
+	}
+
+	return false
+}
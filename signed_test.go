@@ -0,0 +1,87 @@
+package durago
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDurationSigned(t *testing.T) {
+	cases := []struct {
+		Duration     string
+		Expected     string
+		TimeDuration time.Duration
+	}{
+		{Duration: "PT-1H30M", Expected: "PT-1H30M", TimeDuration: -30 * time.Minute},
+		{Duration: "P1DT-2H", Expected: "P1DT-2H", TimeDuration: 22 * time.Hour},
+		{Duration: "P-1Y-2M", Expected: "P-1Y-2M", TimeDuration: -(periodYear + 2*periodMonth)},
+		{Duration: "P1MT-1M", Expected: "P1MT-1M", TimeDuration: periodMonth - time.Minute},
+		{Duration: "PT1M-1.5S", Expected: "PT1M-1.5S", TimeDuration: 58500 * time.Millisecond},
+		{Duration: "-PT-1H30M", Expected: "-PT-1H30M", TimeDuration: 30 * time.Minute},
+		{Duration: "PT1H-60M", Expected: "PT1H-60M", TimeDuration: 0},
+		{Duration: "P1W2D", Expected: "P1W2D", TimeDuration: 9 * periodDay},
+		{Duration: "PT0S", Expected: "PT0S", TimeDuration: 0},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDurationSigned(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse %s; got %v", c.Duration, err)
+		}
+
+		if d.String() != c.Expected {
+			t.Fatalf("expected %s to format as %s; got %s", c.Duration, c.Expected, d.String())
+		}
+
+		if d.GetTimeDuration() != c.TimeDuration {
+			t.Fatalf("expected %s to be %d; got %d", c.Duration, c.TimeDuration, d.GetTimeDuration())
+		}
+	}
+}
+
+func TestSignedDuration_Duration(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected string
+	}{
+		{Duration: "PT-1H30M", Expected: "-PT30M"},
+		{Duration: "P1DT-2H", Expected: "PT22H"},
+		{Duration: "P-1Y-2M", Expected: "-P1Y2M"},
+		{Duration: "-PT-1H-30M", Expected: "PT1H30M"},
+		{Duration: "P1W2D", Expected: "P1W2D"},
+		{Duration: "PT1H-60M", Expected: "PT0S"},
+		{Duration: "PT0S", Expected: "PT0S"},
+	}
+
+	for _, c := range cases {
+		s, err := ParseDurationSigned(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse %s; got %v", c.Duration, err)
+		}
+
+		d := s.Duration()
+		if d.String() != c.Expected {
+			t.Fatalf("expected %s to fold into %s; got %s", c.Duration, c.Expected, d.String())
+		}
+
+		if d.GetTimeDuration() != s.GetTimeDuration() {
+			t.Fatalf("expected %s to keep %d; got %d", c.Duration, s.GetTimeDuration(), d.GetTimeDuration())
+		}
+
+		if _, err := ParseDuration(d.String()); err != nil {
+			t.Fatalf("expected %s to round trip through ParseDuration; got %v", d.String(), err)
+		}
+	}
+}
+
+func TestParseDurationSigned_Invalid(t *testing.T) {
+	for _, s := range []string{"P--1D", "P-D", "P1-D", "-", "P-T1H", "PT1S-1S"} {
+		if _, err := ParseDurationSigned(s); !errors.Is(err, ErrInvalidFormat) {
+			t.Fatalf("expected %s to fail with ErrInvalidFormat; got %v", s, err)
+		}
+	}
+
+	if _, err := ParseDuration("PT-1H30M"); err == nil {
+		t.Fatalf("expected ParseDuration to reject component signs")
+	}
+}
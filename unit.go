@@ -0,0 +1,121 @@
+package durago
+
+import "iter"
+
+// Unit identifies a duration component. Unlike designators it distinguishes months from minutes,
+// units are ordered by their size so they can be compared.
+type Unit int
+
+const (
+	UnitNone Unit = iota
+	UnitSecond
+	UnitMinute
+	UnitHour
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitYear
+)
+
+var unitNames = [...]string{
+	UnitNone:   "none",
+	UnitSecond: "second",
+	UnitMinute: "minute",
+	UnitHour:   "hour",
+	UnitDay:    "day",
+	UnitWeek:   "week",
+	UnitMonth:  "month",
+	UnitYear:   "year",
+}
+
+// String returns the lowercase name of the unit.
+func (u Unit) String() string {
+	if u < 0 || int(u) >= len(unitNames) {
+		return "unknown"
+	}
+
+	return unitNames[u]
+}
+
+// LargestUnit returns the most significant non-zero component of the duration, UnitNone for a zero duration.
+func (d *Duration) LargestUnit() Unit {
+	switch {
+	case d.years != 0:
+		return UnitYear
+	case d.months != 0:
+		return UnitMonth
+	case d.weeks != 0:
+		return UnitWeek
+	case d.days != 0:
+		return UnitDay
+	case d.hours != 0:
+		return UnitHour
+	case d.minutes != 0:
+		return UnitMinute
+	case d.seconds != 0:
+		return UnitSecond
+	}
+
+	return UnitNone
+}
+
+// Units returns an iterator over the non-zero components of the duration as unit and value pairs
+// in ISO8601 order, from years down to seconds. Values are magnitudes, the sign isn't applied.
+func (d *Duration) Units() iter.Seq2[Unit, float64] {
+	return func(yield func(Unit, float64) bool) {
+		components := [...]struct {
+			unit  Unit
+			value float64
+		}{
+			{UnitYear, float64(d.years)},
+			{UnitMonth, float64(d.months)},
+			{UnitWeek, float64(d.weeks)},
+			{UnitDay, float64(d.days)},
+			{UnitHour, float64(d.hours)},
+			{UnitMinute, float64(d.minutes)},
+			{UnitSecond, d.seconds},
+		}
+
+		for _, c := range components {
+			if c.value != 0 && !yield(c.unit, c.value) {
+				return
+			}
+		}
+	}
+}
+
+// PresentUnits returns the units String writes, i.e. the non-zero components, plus those whose designators
+// were written with a zero value in the parsed input, such as the years of P0Y5D, from the largest to the smallest.
+// Sub-second designators count as seconds. A zero duration without explicit designators returns nil.
+func (d *Duration) PresentUnits() []Unit {
+	present := d.nonZeroUnits() | d.zeros
+
+	var units []Unit
+	for u := UnitYear; u > UnitNone; u-- {
+		if present&(1<<u) != 0 {
+			units = append(units, u)
+		}
+	}
+
+	return units
+}
+
+// StringPresent returns the ISO8601 duration string like String, but also writes zero-valued components whose
+// designators appeared in the parsed input, so P0Y0M5DT0H re-emits as is instead of P5D.
+func (d *Duration) StringPresent() string {
+	if d.zeros == 0 {
+		return d.String()
+	}
+
+	return string(d.appendComponents(make([]byte, 0, 20), -1, d.zeros))
+}
+
+// nonZeroUnits returns a mask with the bit 1<<Unit set for every non-zero component.
+func (d *Duration) nonZeroUnits() uint8 {
+	var mask uint8
+	for unit := range d.Units() {
+		mask |= 1 << unit
+	}
+
+	return mask
+}
@@ -0,0 +1,120 @@
+package durago
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDuration_LargestUnit(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Expected Unit
+	}{
+		{Duration: "PT0S", Expected: UnitNone},
+		{Duration: "PT0.5S", Expected: UnitSecond},
+		{Duration: "PT5M1S", Expected: UnitMinute},
+		{Duration: "-PT1H", Expected: UnitHour},
+		{Duration: "P1DT1H", Expected: UnitDay},
+		{Duration: "P2W", Expected: UnitWeek},
+		{Duration: "P1MT1M", Expected: UnitMonth},
+		{Duration: "P1Y2M3W4DT5H6M7S", Expected: UnitYear},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.LargestUnit(); got != c.Expected {
+			t.Fatalf("expected %s largest unit to be %s; got %s", c.Duration, c.Expected, got)
+		}
+	}
+}
+
+func TestUnit_String(t *testing.T) {
+	if UnitMonth.String() != "month" || UnitMinute.String() != "minute" || UnitNone.String() != "none" {
+		t.Fatalf("unexpected unit names %s, %s, %s", UnitMonth, UnitMinute, UnitNone)
+	}
+
+	if Unit(100).String() != "unknown" {
+		t.Fatalf("expected unknown unit; got %s", Unit(100))
+	}
+
+	if !(UnitYear > UnitMonth && UnitMonth > UnitWeek && UnitMinute > UnitSecond && UnitSecond > UnitNone) {
+		t.Fatalf("expected units to be ordered by size")
+	}
+}
+
+func TestDuration_Units(t *testing.T) {
+	d, err := ParseDuration("-P1Y3WT2M7.5S")
+	if err != nil {
+		t.Fatalf("expected to parse duration; got %v", err)
+	}
+
+	var (
+		units  []Unit
+		values []float64
+	)
+
+	for u, v := range d.Units() {
+		units = append(units, u)
+		values = append(values, v)
+	}
+
+	if !slices.Equal(units, []Unit{UnitYear, UnitWeek, UnitMinute, UnitSecond}) {
+		t.Fatalf("unexpected units %v", units)
+	}
+
+	if !slices.Equal(values, []float64{1, 3, 2, 7.5}) {
+		t.Fatalf("unexpected values %v", values)
+	}
+
+	for range d.Units() {
+		break
+	}
+
+	var zero Duration
+	for u, v := range zero.Units() {
+		t.Fatalf("expected no units for zero duration; got %s %v", u, v)
+	}
+}
+
+func TestDuration_PresentUnits(t *testing.T) {
+	cases := []struct {
+		Duration string
+		Units    []Unit
+		Expected string
+	}{
+		{Duration: "P0Y5DT0S", Units: []Unit{UnitYear, UnitDay, UnitSecond}, Expected: "P0Y5DT0S"},
+		{Duration: "P0Y0M0DT1H0M0S", Units: []Unit{UnitYear, UnitMonth, UnitDay, UnitHour, UnitMinute, UnitSecond}, Expected: "P0Y0M0DT1H0M0S"},
+		{Duration: "-P0Y1M", Units: []Unit{UnitYear, UnitMonth}, Expected: "-P0Y1M"},
+		{Duration: "P1D", Units: []Unit{UnitDay}, Expected: "P1D"},
+		{Duration: "P0D", Units: []Unit{UnitDay}, Expected: "P0D"},
+		{Duration: "PT0S", Units: nil, Expected: "PT0S"},
+	}
+
+	for _, c := range cases {
+		d, err := ParseDuration(c.Duration)
+		if err != nil {
+			t.Fatalf("expected to parse duration; got %v", err)
+		}
+
+		if got := d.PresentUnits(); !slices.Equal(got, c.Units) {
+			t.Fatalf("expected %s to have units %v; got %v", c.Duration, c.Units, got)
+		}
+
+		if got := d.StringPresent(); got != c.Expected {
+			t.Fatalf("expected %s to re-emit as %s; got %s", c.Duration, c.Expected, got)
+		}
+	}
+
+	d, _ := ParseDurationExtended("PT1M0MS")
+	if got := d.PresentUnits(); !slices.Equal(got, []Unit{UnitMinute, UnitSecond}) {
+		t.Fatalf("expected sub-seconds to count as seconds; got %v", got)
+	}
+
+	if got := Hours(2).Add(Minutes(0)).PresentUnits(); !slices.Equal(got, []Unit{UnitHour}) {
+		t.Fatalf("expected computed durations to report their non-zero units; got %v", got)
+	}
+}
@@ -0,0 +1,63 @@
+package durago
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrInvalidDuration = errors.New("invalid duration")
+	ErrNotNormalized   = errors.New("not normalized")
+)
+
+// Validate checks the structural invariants of the duration, which matters for values built with FromComponents
+// or decoded from untrusted sources: all components must be non-negative, seconds must be finite
+// and the duration must not overflow. The first violation is returned wrapping ErrInvalidDuration or ErrOverflow.
+func (d *Duration) Validate() error {
+	components := []struct {
+		value int
+		name  string
+	}{
+		{d.years, "years"},
+		{d.months, "months"},
+		{d.weeks, "weeks"},
+		{d.days, "days"},
+		{d.hours, "hours"},
+		{d.minutes, "minutes"},
+	}
+
+	for _, c := range components {
+		if c.value < 0 {
+			return fmt.Errorf("%w: negative %s", ErrInvalidDuration, c.name)
+		}
+	}
+
+	if math.IsNaN(d.seconds) || math.IsInf(d.seconds, 0) {
+		return fmt.Errorf("%w: seconds not finite", ErrInvalidDuration)
+	}
+
+	if d.seconds < 0 {
+		return fmt.Errorf("%w: negative seconds", ErrInvalidDuration)
+	}
+
+	if d.Overflows() {
+		return fmt.Errorf("duration %w", ErrOverflow)
+	}
+
+	return nil
+}
+
+// ValidateNormalized runs Validate and additionally requires all components to be within
+// their natural ranges as reported by IsNormalized, returning ErrNotNormalized otherwise.
+func (d *Duration) ValidateNormalized() error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	if !d.IsNormalized() {
+		return fmt.Errorf("duration %w", ErrNotNormalized)
+	}
+
+	return nil
+}
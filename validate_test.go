@@ -0,0 +1,57 @@
+package durago
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDuration_Validate(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Duration    *Duration
+		ExpectedErr error
+	}{
+		{Name: "zero", Duration: &Duration{}},
+		{Name: "parsed", Duration: FromComponents(Components{Years: 1, Minutes: 90, Seconds: 1.5})},
+		{Name: "max", Duration: MaxDuration()},
+		{Name: "negative flag", Duration: FromComponents(Components{Days: 1, Negative: true})},
+		{Name: "negative days", Duration: FromComponents(Components{Days: -1}), ExpectedErr: ErrInvalidDuration},
+		{Name: "negative seconds", Duration: FromComponents(Components{Seconds: -1}), ExpectedErr: ErrInvalidDuration},
+		{Name: "nan seconds", Duration: &Duration{seconds: math.NaN()}, ExpectedErr: ErrInvalidDuration},
+		{Name: "infinite seconds", Duration: &Duration{seconds: math.Inf(1)}, ExpectedErr: ErrInvalidDuration},
+		{Name: "overflow", Duration: FromComponents(Components{Years: 300}), ExpectedErr: ErrOverflow},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := c.Duration.Validate()
+			if c.ExpectedErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, c.ExpectedErr) {
+				t.Fatalf("expected error %v; got %v", c.ExpectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDuration_ValidateNormalized(t *testing.T) {
+	d, _ := ParseDuration("P1Y11MT59M59S")
+	if err := d.ValidateNormalized(); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	d, _ = ParseDuration("PT90M")
+	if err := d.ValidateNormalized(); !errors.Is(err, ErrNotNormalized) {
+		t.Fatalf("expected error %v; got %v", ErrNotNormalized, err)
+	}
+
+	if err := FromComponents(Components{Hours: -1}).ValidateNormalized(); !errors.Is(err, ErrInvalidDuration) {
+		t.Fatalf("expected error %v; got %v", ErrInvalidDuration, err)
+	}
+}